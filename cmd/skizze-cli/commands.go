@@ -0,0 +1,359 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/skizzehq/goskizze/skizze"
+)
+
+// command is a skizze-cli subcommand, such as "domains list".
+type command struct {
+	name string
+	args string
+	help string
+
+	minArgs int
+	// maxArgs is the maximum number of arguments, or -1 for no limit.
+	maxArgs int
+
+	// run defines the flags of the command on fs and returns the function which runs it
+	// once the flags have been parsed. It is nil for the shell.
+	run func(fs *flag.FlagSet) runFunc
+}
+
+type runFunc func(ctx context.Context, c *skizze.Client, args []string) (*table, error)
+
+var commands = []*command{
+	{name: "domains list", help: "list domains", run: domainsList},
+	{name: "domains create", args: "[flags] NAME", help: "create a domain", minArgs: 1, maxArgs: 1, run: domainsCreate},
+	{name: "domains delete", args: "NAME", help: "delete a domain", minArgs: 1, maxArgs: 1, run: domainsDelete},
+	{name: "domains get", args: "NAME", help: "show the sketches of a domain", minArgs: 1, maxArgs: 1, run: domainsGet},
+	{name: "sketches list", args: "[-type TYPE]", help: "list sketches", run: sketchesList},
+	{name: "sketches create", args: "[flags] NAME TYPE", help: "create a sketch", minArgs: 2, maxArgs: 2, run: sketchesCreate},
+	{name: "sketches delete", args: "NAME TYPE", help: "delete a sketch", minArgs: 2, maxArgs: 2, run: sketchesDelete},
+	{name: "sketches get", args: "NAME TYPE", help: "show a sketch", minArgs: 2, maxArgs: 2, run: sketchesGet},
+	{name: "add", args: "[-type TYPE] NAME VALUE...", help: "add values to a domain, or to a sketch with -type", minArgs: 2, maxArgs: -1, run: add},
+	{name: "memb", args: "NAME VALUE...", help: "test values for membership", minArgs: 2, maxArgs: -1, run: memb},
+	{name: "freq", args: "NAME VALUE...", help: "count the occurrences of values", minArgs: 2, maxArgs: -1, run: freq},
+	{name: "rank", args: "NAME", help: "show the top ranked values", minArgs: 1, maxArgs: 1, run: rank},
+	{name: "card", args: "NAME...", help: "count the distinct values", minArgs: 1, maxArgs: -1, run: card},
+	{name: "snapshot create", args: "[-wait]", help: "take a snapshot", run: snapshotCreate},
+	{name: "snapshot status", help: "show the status of the last snapshot", run: snapshotStatus},
+	{name: "shell", help: "start an interactive shell"},
+}
+
+// findCommand returns the command named by the leading words of args and the remaining
+// arguments, or nil if there is none.
+func findCommand(args []string) (*command, []string) {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) < len(words) {
+			continue
+		}
+		if strings.Join(args[:len(words)], " ") == cmd.name {
+			return cmd, args[len(words):]
+		}
+	}
+	return nil, nil
+}
+
+func printCommands(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %v %v\t%v\n", cmd.name, cmd.args, cmd.help)
+	}
+	tw.Flush()
+}
+
+func domainsList(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		names, err := c.ListDomainsContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		t := newTable("name")
+		for _, name := range names {
+			t.add(name)
+		}
+		return t, nil
+	}
+}
+
+func domainsCreate(fs *flag.FlagSet) runFunc {
+	props := skizze.DomainProperties{
+		MembershipProperties: skizze.DefaultProperties(skizze.Membership),
+		FrequencyProperties:  skizze.DefaultProperties(skizze.Frequency),
+		RankingsProperties:   skizze.DefaultProperties(skizze.Ranking),
+	}
+	fs.Int64Var(&props.MembershipProperties.MaxUniqueItems, "memb-unique", props.MembershipProperties.MaxUniqueItems, "maximum unique items of the membership sketch")
+	errorRateVar(fs, &props.MembershipProperties.ErrorRate, "memb-error-rate", "error rate of the membership sketch")
+	fs.Int64Var(&props.FrequencyProperties.MaxUniqueItems, "freq-unique", props.FrequencyProperties.MaxUniqueItems, "maximum unique items of the frequency sketch")
+	errorRateVar(fs, &props.FrequencyProperties.ErrorRate, "freq-error-rate", "error rate of the frequency sketch")
+	fs.Int64Var(&props.RankingsProperties.Size, "rank-size", props.RankingsProperties.Size, "number of values tracked by the ranking sketch")
+
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		var d *skizze.Domain
+		var err error
+		if fs.NFlag() == 0 {
+			d, err = c.CreateDomainContext(ctx, args[0])
+		} else {
+			d, err = c.CreateDomainWithPropertiesContext(ctx, args[0], &props)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return sketchTable(d.Sketches), nil
+	}
+}
+
+func domainsDelete(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		return nil, c.DeleteDomainContext(ctx, args[0])
+	}
+}
+
+func domainsGet(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		d, err := c.GetDomainContext(ctx, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return sketchTable(d.Sketches), nil
+	}
+}
+
+func sketchesList(fs *flag.FlagSet) runFunc {
+	typ := fs.String("type", "", "only list sketches of this type")
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		var sketches []*skizze.Sketch
+		var err error
+		if *typ == "" {
+			sketches, err = c.ListAllContext(ctx)
+		} else {
+			var t skizze.SketchType
+			if t, err = skizze.ParseSketchType(*typ); err != nil {
+				return nil, err
+			}
+			sketches, err = c.ListSketchesContext(ctx, t)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return sketchTable(sketches), nil
+	}
+}
+
+func sketchesCreate(fs *flag.FlagSet) runFunc {
+	unique := fs.Int64("unique", 0, "maximum unique items of a membership or frequency sketch")
+	var errorRate float32
+	errorRateVar(fs, &errorRate, "error-rate", "error rate of a membership or frequency sketch")
+	size := fs.Int64("size", 0, "number of values tracked by a ranking sketch")
+
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		t, err := skizze.ParseSketchType(args[1])
+		if err != nil {
+			return nil, err
+		}
+		var p *skizze.Properties
+		if fs.NFlag() > 0 {
+			// Properties not set with flags keep their defaults.
+			props := skizze.DefaultProperties(t)
+			if *unique != 0 {
+				props.MaxUniqueItems = *unique
+			}
+			if errorRate != 0 {
+				props.ErrorRate = errorRate
+			}
+			if *size != 0 {
+				props.Size = *size
+			}
+			p = &props
+		}
+		s, err := c.CreateSketchContext(ctx, args[0], t, p)
+		if err != nil {
+			return nil, err
+		}
+		return sketchTable([]*skizze.Sketch{s}), nil
+	}
+}
+
+func sketchesDelete(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		t, err := skizze.ParseSketchType(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.DeleteSketchContext(ctx, args[0], t)
+	}
+}
+
+func sketchesGet(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		t, err := skizze.ParseSketchType(args[1])
+		if err != nil {
+			return nil, err
+		}
+		s, err := c.GetSketchContext(ctx, args[0], t)
+		if err != nil {
+			return nil, err
+		}
+		return sketchTable([]*skizze.Sketch{s}), nil
+	}
+}
+
+func add(fs *flag.FlagSet) runFunc {
+	typ := fs.String("type", "", "add to the sketch of this type rather than a domain")
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		if *typ == "" {
+			return nil, c.AddToDomainContext(ctx, args[0], args[1:]...)
+		}
+		t, err := skizze.ParseSketchType(*typ)
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.AddToSketchContext(ctx, args[0], t, args[1:]...)
+	}
+}
+
+func memb(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		results, err := c.GetMembershipContext(ctx, args[0], args[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		t := newTable("value", "member")
+		for _, r := range results {
+			t.add(r.Value, r.IsMember)
+		}
+		return t, nil
+	}
+}
+
+func freq(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		results, err := c.GetFrequencyContext(ctx, args[0], args[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		t := newTable("value", "count")
+		for _, r := range results {
+			t.add(r.Value, r.Count)
+		}
+		return t, nil
+	}
+}
+
+func rank(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		results, err := c.GetRankingsContext(ctx, args[0])
+		if err != nil {
+			return nil, err
+		}
+		t := newTable("rank", "value", "count")
+		for i, r := range results {
+			t.add(i+1, r.Value, r.Count)
+		}
+		return t, nil
+	}
+}
+
+func card(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		results, err := c.GetMultiCardinalityContext(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		t := newTable("name", "cardinality")
+		for i, r := range results {
+			t.add(args[i], r)
+		}
+		return t, nil
+	}
+}
+
+func snapshotCreate(fs *flag.FlagSet) runFunc {
+	wait := fs.Bool("wait", false, "wait for the snapshot to finish, up to -timeout")
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		s, err := c.CreateSnapshotContext(ctx)
+		if err == nil && *wait {
+			s, err = c.WaitForSnapshot(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return snapshotTable(s), nil
+	}
+}
+
+func snapshotStatus(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, c *skizze.Client, args []string) (*table, error) {
+		s, err := c.GetSnapshotContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return snapshotTable(s), nil
+	}
+}
+
+func sketchTable(sketches []*skizze.Sketch) *table {
+	t := newTable("name", "type", "maxUniqueItems", "errorRate", "size")
+	for _, s := range sketches {
+		p := s.Properties
+		if p == nil {
+			p = &skizze.Properties{}
+		}
+		t.add(s.Name, s.Type.String(), p.MaxUniqueItems, p.ErrorRate, p.Size)
+	}
+	return t
+}
+
+var snapshotStates = map[skizze.SnapshotState]string{
+	skizze.Pending:    "pending",
+	skizze.InProgress: "in progress",
+	skizze.Successful: "successful",
+	skizze.Failed:     "failed",
+}
+
+func snapshotTable(s *skizze.Snapshot) *table {
+	state, ok := snapshotStates[s.Status]
+	if !ok {
+		state = "unknown"
+	}
+	var ts string
+	if !s.Timestamp.IsZero() {
+		ts = s.Timestamp.UTC().Format(time.RFC3339)
+	}
+	t := newTable("status", "message", "timestamp")
+	t.add(state, s.Message, ts)
+	return t
+}
+
+// errorRateVar defines a float32 flag for an error rate.
+func errorRateVar(fs *flag.FlagSet, p *float32, name, usage string) {
+	fs.Var((*float32Value)(p), name, usage)
+}
+
+type float32Value float32
+
+func (f *float32Value) String() string {
+	if f == nil {
+		return "0"
+	}
+	return fmt.Sprint(float32(*f))
+}
+
+func (f *float32Value) Set(s string) error {
+	var v float32
+	if _, err := fmt.Sscan(s, &v); err != nil {
+		return err
+	}
+	*f = float32Value(v)
+	return nil
+}
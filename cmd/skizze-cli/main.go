@@ -0,0 +1,162 @@
+// Command skizze-cli operates a Skizze server from the command line.
+//
+// Usage:
+//
+//     skizze-cli [flags] <command> [arguments]
+//
+// Run skizze-cli -h for the list of commands. Results are printed as a table, or as
+// JSON or CSV with -o, so the output can be used in scripts:
+//
+//     skizze-cli -insecure domains create users
+//     skizze-cli -insecure add users alvin simon theodore
+//     skizze-cli -insecure -o json rank users
+//
+// The shell command starts an interactive shell, with history and tab completion of
+// commands and of domain and sketch names.
+//
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/skizzehq/goskizze/skizze"
+)
+
+const defaultAddr = "127.0.0.1:3596"
+
+// errUsage is returned by commands called with the wrong arguments. The usage of the
+// command has already been printed.
+var errUsage = errors.New("usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("skizze-cli", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", envOr("SKIZZE_ADDR", defaultAddr), "address of the Skizze server, or $SKIZZE_ADDR")
+	insecure := fs.Bool("insecure", false, "connect without transport security")
+	caFile := fs.String("ca", "", "PEM file of certificate authorities used to verify the server")
+	certFile := fs.String("cert", "", "PEM client certificate for mutual TLS")
+	keyFile := fs.String("key", "", "PEM client key for mutual TLS")
+	serverName := fs.String("server-name", "", "host name used to verify the server certificate")
+	token := fs.String("token", os.Getenv("SKIZZE_TOKEN"), "bearer token sent with every request, or $SKIZZE_TOKEN")
+	timeout := fs.Duration("timeout", 10*time.Second, "timeout of each request")
+	output := fs.String("o", string(formatTable), "output format: table, json or csv")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: skizze-cli [flags] <command> [arguments]\n\nCommands:\n")
+		printCommands(stderr)
+		fmt.Fprintf(stderr, "\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	f, err := parseFormat(*output)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	cmd, cmdArgs := findCommand(fs.Args())
+	if cmd == nil {
+		fmt.Fprintf(stderr, "skizze-cli: unknown command %q\n", strings.Join(fs.Args(), " "))
+		fs.Usage()
+		return 2
+	}
+
+	opts := skizze.Options{
+		Insecure:   *insecure,
+		CAFile:     *caFile,
+		CertFile:   *certFile,
+		KeyFile:    *keyFile,
+		ServerName: *serverName,
+	}
+	if *token != "" {
+		opts.PerRPCCredentials = skizze.BearerToken(*token)
+	}
+	client, err := skizze.Dial(*addr, opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "skizze-cli: %v\n", err)
+		return 1
+	}
+	defer client.Close()
+
+	e := &env{client: client, timeout: *timeout, format: f, stdout: stdout, stderr: stderr}
+	if cmd.run == nil {
+		err = e.shell()
+	} else {
+		err = e.exec(cmd, cmdArgs)
+	}
+	if err != nil {
+		if err == errUsage {
+			return 2
+		}
+		fmt.Fprintf(stderr, "skizze-cli: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// env is the state shared by commands.
+type env struct {
+	client  *skizze.Client
+	timeout time.Duration
+	format  format
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+// exec runs cmd with its arguments, printing the resulting table.
+func (e *env) exec(cmd *command, args []string) error {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "Usage: %v %v\n", cmd.name, cmd.args)
+		fs.PrintDefaults()
+	}
+	run := cmd.run(fs)
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() < cmd.minArgs || (cmd.maxArgs >= 0 && fs.NArg() > cmd.maxArgs) {
+		fs.Usage()
+		return errUsage
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	t, err := run(ctx, e.client, fs.Args())
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return nil
+	}
+	return t.write(e.stdout, e.format)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skizzehq/goskizze/skizze/skizzetest"
+)
+
+type cli struct {
+	t   *testing.T
+	srv *skizzetest.Server
+}
+
+// run executes the command line args against the test server, returning stdout and the
+// exit status.
+func (c *cli) run(args ...string) (string, int) {
+	var stdout, stderr bytes.Buffer
+	status := run(append([]string{"-addr", c.srv.Addr, "-insecure"}, args...), &stdout, &stderr)
+	if status != 0 {
+		c.t.Logf("%v: %v", args, stderr.String())
+	}
+	return stdout.String(), status
+}
+
+func newCLI(t *testing.T) *cli {
+	return &cli{t: t, srv: skizzetest.NewServer()}
+}
+
+func TestDomains(t *testing.T) {
+	assert := assert.New(t)
+	c := newCLI(t)
+	defer c.srv.Close()
+
+	_, status := c.run("domains", "create", "-rank-size", "10", "users")
+	assert.Equal(0, status)
+
+	out, status := c.run("domains", "list")
+	assert.Equal(0, status)
+	assert.Equal("NAME\nusers\n", out)
+
+	out, status = c.run("-o", "csv", "domains", "get", "users")
+	assert.Equal(0, status)
+	assert.Contains(out, "name,type,maxUniqueItems,errorRate,size\n")
+	assert.Contains(out, "users,ranking,0,0,10\n")
+
+	_, status = c.run("domains", "delete", "users")
+	assert.Equal(0, status)
+	_, status = c.run("domains", "get", "users")
+	assert.Equal(1, status)
+}
+
+func TestSketches(t *testing.T) {
+	assert := assert.New(t)
+	c := newCLI(t)
+	defer c.srv.Close()
+
+	_, status := c.run("sketches", "create", "-size", "5", "top", "rank")
+	assert.Equal(0, status)
+	_, status = c.run("sketches", "create", "logins", "card")
+	assert.Equal(0, status)
+	_, status = c.run("sketches", "create", "bad", "bogus")
+	assert.Equal(1, status)
+
+	out, status := c.run("-o", "json", "sketches", "list", "-type", "rank")
+	assert.Equal(0, status)
+	var sketches []map[string]interface{}
+	assert.Nil(json.Unmarshal([]byte(out), &sketches))
+	assert.Equal(1, len(sketches))
+	assert.Equal("top", sketches[0]["name"])
+	assert.Equal(float64(5), sketches[0]["size"])
+
+	_, status = c.run("sketches", "delete", "top", "ranking")
+	assert.Equal(0, status)
+	_, status = c.run("sketches", "get", "top", "ranking")
+	assert.Equal(1, status)
+}
+
+func TestQueries(t *testing.T) {
+	assert := assert.New(t)
+	c := newCLI(t)
+	defer c.srv.Close()
+
+	_, status := c.run("domains", "create", "users")
+	assert.Equal(0, status)
+	_, status = c.run("add", "users", "alvin", "simon", "alvin")
+	assert.Equal(0, status)
+
+	out, status := c.run("-o", "csv", "memb", "users", "alvin", "gary")
+	assert.Equal(0, status)
+	assert.Equal("value,member\nalvin,true\ngary,false\n", out)
+
+	out, status = c.run("-o", "csv", "freq", "users", "alvin")
+	assert.Equal(0, status)
+	assert.Equal("value,count\nalvin,2\n", out)
+
+	out, status = c.run("-o", "csv", "rank", "users")
+	assert.Equal(0, status)
+	assert.Equal("rank,value,count\n1,alvin,2\n2,simon,1\n", out)
+
+	out, status = c.run("-o", "json", "card", "users")
+	assert.Equal(0, status)
+	var cards []map[string]interface{}
+	assert.Nil(json.Unmarshal([]byte(out), &cards))
+	assert.Equal([]map[string]interface{}{{"name": "users", "cardinality": float64(2)}}, cards)
+
+	_, status = c.run("sketches", "create", "top", "rank")
+	assert.Equal(0, status)
+	_, status = c.run("add", "-type", "rank", "top", "theodore")
+	assert.Equal(0, status)
+	out, status = c.run("-o", "csv", "rank", "top")
+	assert.Equal(0, status)
+	assert.Equal("rank,value,count\n1,theodore,1\n", out)
+}
+
+func TestSnapshot(t *testing.T) {
+	assert := assert.New(t)
+	c := newCLI(t)
+	defer c.srv.Close()
+
+	out, status := c.run("-o", "csv", "snapshot", "create", "-wait")
+	assert.Equal(0, status)
+	assert.Contains(out, "status,message,timestamp\nsuccessful,")
+
+	out, status = c.run("snapshot", "status")
+	assert.Equal(0, status)
+	assert.Contains(out, "successful")
+}
+
+func TestUsage(t *testing.T) {
+	assert := assert.New(t)
+	c := newCLI(t)
+	defer c.srv.Close()
+
+	_, status := c.run()
+	assert.Equal(2, status)
+	_, status = c.run("bogus")
+	assert.Equal(2, status)
+	_, status = c.run("domains", "create")
+	assert.Equal(2, status)
+	_, status = c.run("-o", "xml", "domains", "list")
+	assert.Equal(2, status)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// table is the result of a command, printed in the format chosen with -o.
+type table struct {
+	header []string
+	rows   [][]interface{}
+}
+
+func newTable(header ...string) *table {
+	return &table{header: header}
+}
+
+func (t *table) add(values ...interface{}) {
+	t.rows = append(t.rows, values)
+}
+
+type format string
+
+const (
+	formatTable format = "table"
+	formatJSON  format = "json"
+	formatCSV   format = "csv"
+)
+
+func parseFormat(s string) (format, error) {
+	switch f := format(strings.ToLower(s)); f {
+	case formatTable, formatJSON, formatCSV:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown output format %q, expected table, json or csv", s)
+}
+
+func (t *table) write(w io.Writer, f format) error {
+	switch f {
+	case formatJSON:
+		return t.writeJSON(w)
+	case formatCSV:
+		return t.writeCSV(w)
+	default:
+		return t.writeTable(w)
+	}
+}
+
+func (t *table) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(t.header, "\t")))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(t.strings(row), "\t"))
+	}
+	return tw.Flush()
+}
+
+func (t *table) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.header); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		if err := cw.Write(t.strings(row)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeJSON writes the rows as an array of objects keyed by the header, keeping the
+// types of the values.
+func (t *table) writeJSON(w io.Writer) error {
+	objs := make([]map[string]interface{}, 0, len(t.rows))
+	for _, row := range t.rows {
+		obj := make(map[string]interface{}, len(t.header))
+		for i, h := range t.header {
+			obj[h] = row[i]
+		}
+		objs = append(objs, obj)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(objs)
+}
+
+func (t *table) strings(row []interface{}) []string {
+	ret := make([]string, len(row))
+	for i, v := range row {
+		ret[i] = fmt.Sprint(v)
+	}
+	return ret
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/peterh/liner"
+	"golang.org/x/net/context"
+)
+
+const shellHelp = `Shell commands:
+  output table|json|csv  change the output format
+  help                   show this help
+  exit                   leave the shell
+`
+
+// shell reads commands from the terminal until it is closed or "exit" is entered. The
+// history is kept in $SKIZZE_HISTORY, or ~/.skizze_history.
+func (e *env) shell() error {
+	line := liner.NewLiner()
+	defer line.Close()
+	line.SetCtrlCAborts(true)
+	line.SetWordCompleter(e.complete)
+
+	history := historyFile()
+	if f, err := os.Open(history); err == nil {
+		line.ReadHistory(f)
+		f.Close()
+	}
+	defer func() {
+		if f, err := os.Create(history); err == nil {
+			line.WriteHistory(f)
+			f.Close()
+		}
+	}()
+
+	for {
+		input, err := line.Prompt("skizze> ")
+		if err == liner.ErrPromptAborted {
+			continue
+		}
+		if err == io.EOF {
+			fmt.Fprintln(e.stdout)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(input) == "" {
+			continue
+		}
+		line.AppendHistory(input)
+		if e.execLine(input) {
+			return nil
+		}
+	}
+}
+
+func historyFile() string {
+	if f := os.Getenv("SKIZZE_HISTORY"); f != "" {
+		return f
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".skizze_history"
+	}
+	return filepath.Join(home, ".skizze_history")
+}
+
+// execLine runs a line entered in the shell, printing any error. It returns true if the
+// shell should exit.
+func (e *env) execLine(input string) bool {
+	args, err := splitLine(input)
+	if err != nil {
+		fmt.Fprintln(e.stderr, err)
+		return false
+	}
+	if len(args) == 0 {
+		return false
+	}
+
+	switch args[0] {
+	case "exit", "quit":
+		return true
+	case "help":
+		printCommands(e.stdout)
+		fmt.Fprintf(e.stdout, "\n%v", shellHelp)
+		return false
+	case "output":
+		if len(args) != 2 {
+			fmt.Fprintln(e.stderr, "Usage: output table|json|csv")
+			return false
+		}
+		f, err := parseFormat(args[1])
+		if err != nil {
+			fmt.Fprintln(e.stderr, err)
+			return false
+		}
+		e.format = f
+		return false
+	}
+
+	cmd, cmdArgs := findCommand(args)
+	if cmd == nil || cmd.run == nil {
+		fmt.Fprintf(e.stderr, "unknown command %q, enter help for the list of commands\n", strings.Join(args, " "))
+		return false
+	}
+	if err := e.exec(cmd, cmdArgs); err != nil && err != errUsage {
+		fmt.Fprintln(e.stderr, err)
+	}
+	return false
+}
+
+// splitLine splits a line into words separated by spaces. Words may be quoted with single
+// or double quotes to include spaces.
+func splitLine(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	var quote rune
+	inWord := false
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+var sketchTypeNames = []string{"membership", "frequency", "ranking", "cardinality"}
+
+// complete returns the completions of the word at pos in line: command names, or the
+// names of domains and sketches fetched from Skizze, or sketch types.
+func (e *env) complete(line string, pos int) (head string, completions []string, tail string) {
+	head, tail = line[:pos], line[pos:]
+	start := strings.LastIndexAny(head, " \t") + 1
+	prefix := head[start:]
+	head = head[:start]
+
+	for _, c := range e.candidates(strings.Fields(head)) {
+		if strings.HasPrefix(c, prefix) {
+			completions = append(completions, c)
+		}
+	}
+	return head, completions, tail
+}
+
+// candidates returns the possible words following words.
+func (e *env) candidates(words []string) []string {
+	cmd, args := findCommand(words)
+	if cmd == nil {
+		return nextCommandWords(words)
+	}
+
+	if len(args) > 0 && args[len(args)-1] == "-type" {
+		return sketchTypeNames
+	}
+	n := 0
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "-") || (i > 0 && args[i-1] == "-type") {
+			continue
+		}
+		n++
+	}
+
+	// The positional parameters of cmd, skipping optional flags in brackets.
+	var params []string
+	depth := 0
+	for _, p := range strings.Fields(cmd.args) {
+		if strings.HasPrefix(p, "[") {
+			depth++
+		}
+		if depth == 0 {
+			params = append(params, p)
+		}
+		if strings.HasSuffix(p, "]") {
+			depth--
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	param := params[len(params)-1]
+	if n < len(params) {
+		param = params[n]
+	}
+
+	switch strings.TrimSuffix(param, "...") {
+	case "TYPE":
+		return sketchTypeNames
+	case "NAME":
+		return e.names(cmd)
+	}
+	return nil
+}
+
+// nextCommandWords returns the words which can follow the partial command words.
+func nextCommandWords(words []string) []string {
+	var ret []string
+	seen := make(map[string]bool)
+	if len(words) == 0 {
+		ret = append(ret, "exit", "help", "output")
+	}
+	for _, cmd := range commands {
+		cmdWords := strings.Fields(cmd.name)
+		if len(cmdWords) <= len(words) || strings.Join(cmdWords[:len(words)], " ") != strings.Join(words, " ") {
+			continue
+		}
+		if w := cmdWords[len(words)]; !seen[w] {
+			seen[w] = true
+			ret = append(ret, w)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// names returns the domain or sketch names an argument of cmd may take.
+func (e *env) names(cmd *command) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	seen := make(map[string]bool)
+	if !strings.HasPrefix(cmd.name, "sketches ") {
+		domains, err := e.client.ListDomainsContext(ctx)
+		if err != nil {
+			return nil
+		}
+		for _, d := range domains {
+			seen[d] = true
+		}
+	}
+	if !strings.HasPrefix(cmd.name, "domains ") {
+		sketches, err := e.client.ListAllContext(ctx)
+		if err != nil {
+			return nil
+		}
+		for _, s := range sketches {
+			seen[s.Name] = true
+		}
+	}
+
+	ret := make([]string, 0, len(seen))
+	for name := range seen {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skizzehq/goskizze/skizze/skizzetest"
+)
+
+func newShellEnv(srv *skizzetest.Server) (*env, *bytes.Buffer, *bytes.Buffer) {
+	var stdout, stderr bytes.Buffer
+	return &env{
+		client:  srv.Client(),
+		timeout: time.Second,
+		format:  formatTable,
+		stdout:  &stdout,
+		stderr:  &stderr,
+	}, &stdout, &stderr
+}
+
+func TestExecLine(t *testing.T) {
+	assert := assert.New(t)
+	srv := skizzetest.NewBufconnServer()
+	defer srv.Close()
+	e, stdout, stderr := newShellEnv(srv)
+	defer e.client.Close()
+
+	assert.False(e.execLine(`domains create "my users"`))
+	assert.False(e.execLine("output csv"))
+	assert.Equal(formatCSV, e.format)
+
+	stdout.Reset()
+	assert.False(e.execLine("domains list"))
+	assert.Equal("name\nmy users\n", stdout.String())
+
+	assert.False(e.execLine("bogus"))
+	assert.Contains(stderr.String(), `unknown command "bogus"`)
+
+	stderr.Reset()
+	assert.False(e.execLine("shell"))
+	assert.Contains(stderr.String(), "unknown command")
+
+	stderr.Reset()
+	assert.False(e.execLine(`add "unterminated`))
+	assert.Contains(stderr.String(), "unterminated quote")
+
+	assert.True(e.execLine("exit"))
+}
+
+func TestSplitLine(t *testing.T) {
+	assert := assert.New(t)
+
+	words, err := splitLine(`add  users 'alvin the chipmunk' "simon"  ""`)
+	assert.Nil(err)
+	assert.Equal([]string{"add", "users", "alvin the chipmunk", "simon", ""}, words)
+}
+
+func TestComplete(t *testing.T) {
+	assert := assert.New(t)
+	srv := skizzetest.NewBufconnServer()
+	defer srv.Close()
+	e, _, _ := newShellEnv(srv)
+	defer e.client.Close()
+
+	assert.False(e.execLine("domains create users"))
+	assert.False(e.execLine("sketches create top rank"))
+
+	complete := func(line string) (string, []string) {
+		head, completions, _ := e.complete(line, len(line))
+		return head, completions
+	}
+
+	head, c := complete("dom")
+	assert.Equal("", head)
+	assert.Equal([]string{"domains"}, c)
+
+	head, c = complete("snapshot ")
+	assert.Equal("snapshot ", head)
+	assert.Equal([]string{"create", "status"}, c)
+
+	_, c = complete("domains get ")
+	assert.Equal([]string{"users"}, c)
+
+	_, c = complete("sketches get ")
+	assert.Equal([]string{"top", "users"}, c)
+
+	_, c = complete("sketches get top r")
+	assert.Equal([]string{"ranking"}, c)
+
+	_, c = complete("memb u")
+	assert.Equal([]string{"users"}, c)
+
+	_, c = complete("add -type ")
+	assert.Equal(sketchTypeNames, c)
+
+	_, c = complete("add -type rank t")
+	assert.Equal([]string{"top"}, c)
+}
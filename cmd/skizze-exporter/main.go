@@ -0,0 +1,89 @@
+// Command skizze-exporter exports statistics of Skizze sketches as Prometheus metrics.
+// See package skizzeprom for the metrics.
+//
+// Usage:
+//
+//     skizze-exporter -listen :9596 -addr 127.0.0.1:3596 -insecure -exclude '^tmp-'
+//
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/net/context"
+
+	"github.com/skizzehq/goskizze/skizze"
+	"github.com/skizzehq/goskizze/skizze/skizzeprom"
+)
+
+func main() {
+	listen := flag.String("listen", ":9596", "address to serve metrics on")
+	addr := flag.String("addr", envOr("SKIZZE_ADDR", "127.0.0.1:3596"), "address of the Skizze server, or $SKIZZE_ADDR")
+	insecure := flag.Bool("insecure", false, "connect to Skizze without transport security")
+	caFile := flag.String("ca", "", "PEM file of certificate authorities used to verify Skizze")
+	certFile := flag.String("cert", "", "PEM client certificate for mutual TLS")
+	keyFile := flag.String("key", "", "PEM client key for mutual TLS")
+	serverName := flag.String("server-name", "", "host name used to verify the Skizze certificate")
+	token := flag.String("token", os.Getenv("SKIZZE_TOKEN"), "bearer token sent to Skizze, or $SKIZZE_TOKEN")
+	interval := flag.Duration("interval", time.Minute, "time between refreshes of the metrics")
+	topN := flag.Int("top", 10, "number of top ranked values exported per ranking sketch")
+	include := flag.String("include", "", "only export sketches whose name matches this regular expression")
+	exclude := flag.String("exclude", "", "do not export sketches whose name matches this regular expression")
+	flag.Parse()
+
+	opts := skizze.Options{
+		Insecure:   *insecure,
+		CAFile:     *caFile,
+		CertFile:   *certFile,
+		KeyFile:    *keyFile,
+		ServerName: *serverName,
+	}
+	if *token != "" {
+		opts.PerRPCCredentials = skizze.BearerToken(*token)
+	}
+	client, err := skizze.Dial(*addr, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	collector := skizzeprom.NewCollector(client, skizzeprom.Options{
+		Interval: *interval,
+		TopN:     *topN,
+		Include:  compile(*include),
+		Exclude:  compile(*exclude),
+		OnError:  func(err error) { log.Printf("Error refreshing metrics: %v", err) },
+	})
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collector)
+	go collector.Run(context.Background())
+
+	http.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	log.Printf("Exporting metrics of Skizze at %v on %v", *addr, *listen)
+	log.Fatal(http.ListenAndServe(*listen, nil))
+}
+
+func compile(expr string) *regexp.Regexp {
+	if expr == "" {
+		return nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		log.Fatalf("Invalid regular expression %q: %v", expr, err)
+	}
+	return re
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
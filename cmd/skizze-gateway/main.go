@@ -0,0 +1,84 @@
+// Command skizze-gateway serves the Skizze API over HTTP with JSON bodies, translating
+// requests to a Skizze server. See package skizzehttp for the endpoints.
+//
+// Usage:
+//
+//     skizze-gateway -listen :8080 -addr 127.0.0.1:3596 -insecure
+//
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/skizzehq/goskizze/skizze"
+	"github.com/skizzehq/goskizze/skizze/skizzehttp"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "address to serve HTTP on")
+	addr := flag.String("addr", envOr("SKIZZE_ADDR", "127.0.0.1:3596"), "address of the Skizze server, or $SKIZZE_ADDR")
+	insecure := flag.Bool("insecure", false, "connect to Skizze without transport security")
+	caFile := flag.String("ca", "", "PEM file of certificate authorities used to verify Skizze")
+	certFile := flag.String("cert", "", "PEM client certificate for mutual TLS")
+	keyFile := flag.String("key", "", "PEM client key for mutual TLS")
+	serverName := flag.String("server-name", "", "host name used to verify the Skizze certificate")
+	token := flag.String("token", os.Getenv("SKIZZE_TOKEN"), "bearer token sent to Skizze, or $SKIZZE_TOKEN")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of each HTTP request")
+	flag.Parse()
+
+	opts := skizze.Options{
+		Insecure:   *insecure,
+		CAFile:     *caFile,
+		CertFile:   *certFile,
+		KeyFile:    *keyFile,
+		ServerName: *serverName,
+	}
+	if *token != "" {
+		opts.PerRPCCredentials = skizze.BearerToken(*token)
+	}
+	client, err := skizze.Dial(*addr, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	srv := &http.Server{
+		Addr:    *listen,
+		Handler: http.TimeoutHandler(skizzehttp.NewHandler(client), *timeout, `{"error": "timeout"}`),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("Error shutting down: %v", err)
+		}
+	}()
+
+	log.Printf("Serving Skizze at %v on %v", *addr, *listen)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+	<-done
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
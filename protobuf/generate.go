@@ -0,0 +1,5 @@
+package protobuf
+
+// skizze.pb.go is generated with the protoc-gen-go of github.com/golang/protobuf, which
+// includes the gRPC service code.
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. skizze.proto
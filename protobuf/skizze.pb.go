@@ -1,64 +1,29 @@
-// Code generated by protoc-gen-go.
-// source: src/datamodel/protobuf/skizze.proto
-// DO NOT EDIT!
-
-/*
-Package protobuf is a generated protocol buffer package.
-
-It is generated from these files:
-	src/datamodel/protobuf/skizze.proto
-
-It has these top-level messages:
-	Empty
-	SketchProperties
-	SketchState
-	Domain
-	Sketch
-	Membership
-	Frequency
-	Rank
-	CreateSnapshotRequest
-	CreateSnapshotReply
-	GetSnapshotRequest
-	GetSnapshotReply
-	ListRequest
-	ListReply
-	ListDomainsReply
-	AddRequest
-	AddReply
-	GetRequest
-	MembershipResult
-	FrequencyResult
-	CardinalityResult
-	RankingsResult
-	GetMembershipReply
-	GetFrequencyReply
-	GetCardinalityReply
-	GetRankingsReply
-*/
-package protobuf
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: skizze.proto
 
-import proto "github.com/golang/protobuf/proto"
-import fmt "fmt"
-import math "math"
+package protobuf
 
 import (
-	context "golang.org/x/net/context"
+	context "context"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-const _ = proto.ProtoPackageIsVersion1
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
-//
-// Enums
-//
 type SketchType int32
 
 const (
@@ -68,36 +33,58 @@ const (
 	SketchType_CARD SketchType = 4
 )
 
-var SketchType_name = map[int32]string{
-	1: "MEMB",
-	2: "FREQ",
-	3: "RANK",
-	4: "CARD",
-}
-var SketchType_value = map[string]int32{
-	"MEMB": 1,
-	"FREQ": 2,
-	"RANK": 3,
-	"CARD": 4,
-}
+// Enum value maps for SketchType.
+var (
+	SketchType_name = map[int32]string{
+		1: "MEMB",
+		2: "FREQ",
+		3: "RANK",
+		4: "CARD",
+	}
+	SketchType_value = map[string]int32{
+		"MEMB": 1,
+		"FREQ": 2,
+		"RANK": 3,
+		"CARD": 4,
+	}
+)
 
 func (x SketchType) Enum() *SketchType {
 	p := new(SketchType)
 	*p = x
 	return p
 }
+
 func (x SketchType) String() string {
-	return proto.EnumName(SketchType_name, int32(x))
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SketchType) Descriptor() protoreflect.EnumDescriptor {
+	return file_skizze_proto_enumTypes[0].Descriptor()
+}
+
+func (SketchType) Type() protoreflect.EnumType {
+	return &file_skizze_proto_enumTypes[0]
+}
+
+func (x SketchType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
-func (x *SketchType) UnmarshalJSON(data []byte) error {
-	value, err := proto.UnmarshalJSONEnum(SketchType_value, data, "SketchType")
+
+// Deprecated: Do not use.
+func (x *SketchType) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
 	if err != nil {
 		return err
 	}
-	*x = SketchType(value)
+	*x = SketchType(num)
 	return nil
 }
-func (SketchType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+// Deprecated: Use SketchType.Descriptor instead.
+func (SketchType) EnumDescriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{0}
+}
 
 type SnapshotStatus int32
 
@@ -108,626 +95,2022 @@ const (
 	SnapshotStatus_FAILED      SnapshotStatus = 4
 )
 
-var SnapshotStatus_name = map[int32]string{
-	1: "PENDING",
-	2: "IN_PROGRESS",
-	3: "SUCCESSFUL",
-	4: "FAILED",
-}
-var SnapshotStatus_value = map[string]int32{
-	"PENDING":     1,
-	"IN_PROGRESS": 2,
-	"SUCCESSFUL":  3,
-	"FAILED":      4,
-}
+// Enum value maps for SnapshotStatus.
+var (
+	SnapshotStatus_name = map[int32]string{
+		1: "PENDING",
+		2: "IN_PROGRESS",
+		3: "SUCCESSFUL",
+		4: "FAILED",
+	}
+	SnapshotStatus_value = map[string]int32{
+		"PENDING":     1,
+		"IN_PROGRESS": 2,
+		"SUCCESSFUL":  3,
+		"FAILED":      4,
+	}
+)
 
 func (x SnapshotStatus) Enum() *SnapshotStatus {
 	p := new(SnapshotStatus)
 	*p = x
 	return p
 }
+
 func (x SnapshotStatus) String() string {
-	return proto.EnumName(SnapshotStatus_name, int32(x))
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SnapshotStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_skizze_proto_enumTypes[1].Descriptor()
+}
+
+func (SnapshotStatus) Type() protoreflect.EnumType {
+	return &file_skizze_proto_enumTypes[1]
 }
-func (x *SnapshotStatus) UnmarshalJSON(data []byte) error {
-	value, err := proto.UnmarshalJSONEnum(SnapshotStatus_value, data, "SnapshotStatus")
+
+func (x SnapshotStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *SnapshotStatus) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
 	if err != nil {
 		return err
 	}
-	*x = SnapshotStatus(value)
+	*x = SnapshotStatus(num)
 	return nil
 }
-func (SnapshotStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
-//
-// Generic Structures
-//
+// Deprecated: Use SnapshotStatus.Descriptor instead.
+func (SnapshotStatus) EnumDescriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{1}
+}
+
 type Empty struct {
-	XXX_unrecognized []byte `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func (m *Empty) Reset()                    { *m = Empty{} }
-func (m *Empty) String() string            { return proto.CompactTextString(m) }
-func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{0}
+}
 
 type SketchProperties struct {
-	MaxUniqueItems   *int64   `protobuf:"varint,1,opt,name=maxUniqueItems" json:"maxUniqueItems,omitempty"`
-	ErrorRate        *float32 `protobuf:"fixed32,2,opt,name=errorRate" json:"errorRate,omitempty"`
-	Size             *int64   `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
-	XXX_unrecognized []byte   `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxUniqueItems *int64   `protobuf:"varint,1,opt,name=maxUniqueItems" json:"maxUniqueItems,omitempty"`
+	ErrorRate      *float32 `protobuf:"fixed32,2,opt,name=errorRate" json:"errorRate,omitempty"`
+	Size           *int64   `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
 }
 
-func (m *SketchProperties) Reset()                    { *m = SketchProperties{} }
-func (m *SketchProperties) String() string            { return proto.CompactTextString(m) }
-func (*SketchProperties) ProtoMessage()               {}
-func (*SketchProperties) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (x *SketchProperties) Reset() {
+	*x = SketchProperties{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func (m *SketchProperties) GetMaxUniqueItems() int64 {
-	if m != nil && m.MaxUniqueItems != nil {
-		return *m.MaxUniqueItems
+func (x *SketchProperties) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SketchProperties) ProtoMessage() {}
+
+func (x *SketchProperties) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SketchProperties.ProtoReflect.Descriptor instead.
+func (*SketchProperties) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SketchProperties) GetMaxUniqueItems() int64 {
+	if x != nil && x.MaxUniqueItems != nil {
+		return *x.MaxUniqueItems
 	}
 	return 0
 }
 
-func (m *SketchProperties) GetErrorRate() float32 {
-	if m != nil && m.ErrorRate != nil {
-		return *m.ErrorRate
+func (x *SketchProperties) GetErrorRate() float32 {
+	if x != nil && x.ErrorRate != nil {
+		return *x.ErrorRate
 	}
 	return 0
 }
 
-func (m *SketchProperties) GetSize() int64 {
-	if m != nil && m.Size != nil {
-		return *m.Size
+func (x *SketchProperties) GetSize() int64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
 	}
 	return 0
 }
 
 type SketchState struct {
-	FillRate         *float32 `protobuf:"fixed32,1,opt,name=fillRate" json:"fillRate,omitempty"`
-	LastSnapshot     *int64   `protobuf:"varint,2,opt,name=lastSnapshot" json:"lastSnapshot,omitempty"`
-	XXX_unrecognized []byte   `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FillRate     *float32 `protobuf:"fixed32,1,opt,name=fillRate" json:"fillRate,omitempty"`
+	LastSnapshot *int64   `protobuf:"varint,2,opt,name=lastSnapshot" json:"lastSnapshot,omitempty"`
+}
+
+func (x *SketchState) Reset() {
+	*x = SketchState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SketchState) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *SketchState) Reset()                    { *m = SketchState{} }
-func (m *SketchState) String() string            { return proto.CompactTextString(m) }
-func (*SketchState) ProtoMessage()               {}
-func (*SketchState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*SketchState) ProtoMessage() {}
 
-func (m *SketchState) GetFillRate() float32 {
-	if m != nil && m.FillRate != nil {
-		return *m.FillRate
+func (x *SketchState) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SketchState.ProtoReflect.Descriptor instead.
+func (*SketchState) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SketchState) GetFillRate() float32 {
+	if x != nil && x.FillRate != nil {
+		return *x.FillRate
 	}
 	return 0
 }
 
-func (m *SketchState) GetLastSnapshot() int64 {
-	if m != nil && m.LastSnapshot != nil {
-		return *m.LastSnapshot
+func (x *SketchState) GetLastSnapshot() int64 {
+	if x != nil && x.LastSnapshot != nil {
+		return *x.LastSnapshot
 	}
 	return 0
 }
 
-// CreateDomain: name:required, propertiess:optional (array = nSketchTypes, order of types above)
-// DeleteDomain: name:required
-// GetDomain   : name:required
 type Domain struct {
-	Name             *string   `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
-	Sketches         []*Sketch `protobuf:"bytes,2,rep,name=sketches" json:"sketches,omitempty"`
-	XXX_unrecognized []byte    `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     *string   `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	Sketches []*Sketch `protobuf:"bytes,2,rep,name=sketches" json:"sketches,omitempty"`
 }
 
-func (m *Domain) Reset()                    { *m = Domain{} }
-func (m *Domain) String() string            { return proto.CompactTextString(m) }
-func (*Domain) ProtoMessage()               {}
-func (*Domain) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (x *Domain) Reset() {
+	*x = Domain{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Domain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (m *Domain) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (*Domain) ProtoMessage() {}
+
+func (x *Domain) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Domain.ProtoReflect.Descriptor instead.
+func (*Domain) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Domain) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
 	return ""
 }
 
-func (m *Domain) GetSketches() []*Sketch {
-	if m != nil {
-		return m.Sketches
+func (x *Domain) GetSketches() []*Sketch {
+	if x != nil {
+		return x.Sketches
 	}
 	return nil
 }
 
-// CreateSketch: name:required, type:required, properties:optional
-// DeleteSketch: name:required, type:required
-// GetSketch   : name:required, type:required
 type Sketch struct {
-	Name             *string           `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
-	Type             *SketchType       `protobuf:"varint,2,req,name=type,enum=protobuf.SketchType" json:"type,omitempty"`
-	Properties       *SketchProperties `protobuf:"bytes,3,opt,name=properties" json:"properties,omitempty"`
-	State            *SketchState      `protobuf:"bytes,4,opt,name=state" json:"state,omitempty"`
-	XXX_unrecognized []byte            `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       *string           `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	Type       *SketchType       `protobuf:"varint,2,req,name=type,enum=protobuf.SketchType" json:"type,omitempty"`
+	Properties *SketchProperties `protobuf:"bytes,3,opt,name=properties" json:"properties,omitempty"`
+	State      *SketchState      `protobuf:"bytes,4,opt,name=state" json:"state,omitempty"`
+}
+
+func (x *Sketch) Reset() {
+	*x = Sketch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sketch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sketch) ProtoMessage() {}
+
+func (x *Sketch) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *Sketch) Reset()                    { *m = Sketch{} }
-func (m *Sketch) String() string            { return proto.CompactTextString(m) }
-func (*Sketch) ProtoMessage()               {}
-func (*Sketch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+// Deprecated: Use Sketch.ProtoReflect.Descriptor instead.
+func (*Sketch) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{4}
+}
 
-func (m *Sketch) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (x *Sketch) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
 	return ""
 }
 
-func (m *Sketch) GetType() SketchType {
-	if m != nil && m.Type != nil {
-		return *m.Type
+func (x *Sketch) GetType() SketchType {
+	if x != nil && x.Type != nil {
+		return *x.Type
 	}
 	return SketchType_MEMB
 }
 
-func (m *Sketch) GetProperties() *SketchProperties {
-	if m != nil {
-		return m.Properties
+func (x *Sketch) GetProperties() *SketchProperties {
+	if x != nil {
+		return x.Properties
 	}
 	return nil
 }
 
-func (m *Sketch) GetState() *SketchState {
-	if m != nil {
-		return m.State
+func (x *Sketch) GetState() *SketchState {
+	if x != nil {
+		return x.State
 	}
 	return nil
 }
 
 type Membership struct {
-	Value            *string `protobuf:"bytes,1,req,name=value" json:"value,omitempty"`
-	IsMember         *bool   `protobuf:"varint,2,req,name=isMember" json:"isMember,omitempty"`
-	XXX_unrecognized []byte  `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value    *string `protobuf:"bytes,1,req,name=value" json:"value,omitempty"`
+	IsMember *bool   `protobuf:"varint,2,req,name=isMember" json:"isMember,omitempty"`
+}
+
+func (x *Membership) Reset() {
+	*x = Membership{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Membership) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *Membership) Reset()                    { *m = Membership{} }
-func (m *Membership) String() string            { return proto.CompactTextString(m) }
-func (*Membership) ProtoMessage()               {}
-func (*Membership) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*Membership) ProtoMessage() {}
 
-func (m *Membership) GetValue() string {
-	if m != nil && m.Value != nil {
-		return *m.Value
+func (x *Membership) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Membership.ProtoReflect.Descriptor instead.
+func (*Membership) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Membership) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
 	}
 	return ""
 }
 
-func (m *Membership) GetIsMember() bool {
-	if m != nil && m.IsMember != nil {
-		return *m.IsMember
+func (x *Membership) GetIsMember() bool {
+	if x != nil && x.IsMember != nil {
+		return *x.IsMember
 	}
 	return false
 }
 
 type Frequency struct {
-	Value            *string `protobuf:"bytes,1,req,name=value" json:"value,omitempty"`
-	Count            *int64  `protobuf:"varint,2,req,name=count" json:"count,omitempty"`
-	XXX_unrecognized []byte  `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *string `protobuf:"bytes,1,req,name=value" json:"value,omitempty"`
+	Count *int64  `protobuf:"varint,2,req,name=count" json:"count,omitempty"`
 }
 
-func (m *Frequency) Reset()                    { *m = Frequency{} }
-func (m *Frequency) String() string            { return proto.CompactTextString(m) }
-func (*Frequency) ProtoMessage()               {}
-func (*Frequency) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (x *Frequency) Reset() {
+	*x = Frequency{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Frequency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Frequency) ProtoMessage() {}
+
+func (x *Frequency) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Frequency.ProtoReflect.Descriptor instead.
+func (*Frequency) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{6}
+}
 
-func (m *Frequency) GetValue() string {
-	if m != nil && m.Value != nil {
-		return *m.Value
+func (x *Frequency) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
 	}
 	return ""
 }
 
-func (m *Frequency) GetCount() int64 {
-	if m != nil && m.Count != nil {
-		return *m.Count
+func (x *Frequency) GetCount() int64 {
+	if x != nil && x.Count != nil {
+		return *x.Count
 	}
 	return 0
 }
 
 type Rank struct {
-	Value            *string `protobuf:"bytes,1,req,name=value" json:"value,omitempty"`
-	Count            *int64  `protobuf:"varint,2,req,name=count" json:"count,omitempty"`
-	XXX_unrecognized []byte  `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *string `protobuf:"bytes,1,req,name=value" json:"value,omitempty"`
+	Count *int64  `protobuf:"varint,2,req,name=count" json:"count,omitempty"`
 }
 
-func (m *Rank) Reset()                    { *m = Rank{} }
-func (m *Rank) String() string            { return proto.CompactTextString(m) }
-func (*Rank) ProtoMessage()               {}
-func (*Rank) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (x *Rank) Reset() {
+	*x = Rank{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func (m *Rank) GetValue() string {
-	if m != nil && m.Value != nil {
-		return *m.Value
+func (x *Rank) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rank) ProtoMessage() {}
+
+func (x *Rank) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rank.ProtoReflect.Descriptor instead.
+func (*Rank) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Rank) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
 	}
 	return ""
 }
 
-func (m *Rank) GetCount() int64 {
-	if m != nil && m.Count != nil {
-		return *m.Count
+func (x *Rank) GetCount() int64 {
+	if x != nil && x.Count != nil {
+		return *x.Count
 	}
 	return 0
 }
 
-// Right now empty but in the future can request specific snapshot location
-// (e.g. S3 or disk) and snapshot options
 type CreateSnapshotRequest struct {
-	XXX_unrecognized []byte `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CreateSnapshotRequest) Reset() {
+	*x = CreateSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotRequest) ProtoMessage() {}
+
+func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *CreateSnapshotRequest) Reset()                    { *m = CreateSnapshotRequest{} }
-func (m *CreateSnapshotRequest) String() string            { return proto.CompactTextString(m) }
-func (*CreateSnapshotRequest) ProtoMessage()               {}
-func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+// Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{8}
+}
 
 type CreateSnapshotReply struct {
-	Status           *SnapshotStatus `protobuf:"varint,1,req,name=status,enum=protobuf.SnapshotStatus" json:"status,omitempty"`
-	StatusMessage    *string         `protobuf:"bytes,2,opt,name=statusMessage" json:"statusMessage,omitempty"`
-	XXX_unrecognized []byte          `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status        *SnapshotStatus `protobuf:"varint,1,req,name=status,enum=protobuf.SnapshotStatus" json:"status,omitempty"`
+	StatusMessage *string         `protobuf:"bytes,2,opt,name=statusMessage" json:"statusMessage,omitempty"`
+}
+
+func (x *CreateSnapshotReply) Reset() {
+	*x = CreateSnapshotReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSnapshotReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *CreateSnapshotReply) Reset()                    { *m = CreateSnapshotReply{} }
-func (m *CreateSnapshotReply) String() string            { return proto.CompactTextString(m) }
-func (*CreateSnapshotReply) ProtoMessage()               {}
-func (*CreateSnapshotReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*CreateSnapshotReply) ProtoMessage() {}
 
-func (m *CreateSnapshotReply) GetStatus() SnapshotStatus {
-	if m != nil && m.Status != nil {
-		return *m.Status
+func (x *CreateSnapshotReply) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotReply.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotReply) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateSnapshotReply) GetStatus() SnapshotStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
 	}
 	return SnapshotStatus_PENDING
 }
 
-func (m *CreateSnapshotReply) GetStatusMessage() string {
-	if m != nil && m.StatusMessage != nil {
-		return *m.StatusMessage
+func (x *CreateSnapshotReply) GetStatusMessage() string {
+	if x != nil && x.StatusMessage != nil {
+		return *x.StatusMessage
 	}
 	return ""
 }
 
-// Empty for now, future can send in id of specific snapshot
 type GetSnapshotRequest struct {
-	XXX_unrecognized []byte `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func (m *GetSnapshotRequest) Reset()                    { *m = GetSnapshotRequest{} }
-func (m *GetSnapshotRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetSnapshotRequest) ProtoMessage()               {}
-func (*GetSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (x *GetSnapshotRequest) Reset() {
+	*x = GetSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotRequest) ProtoMessage() {}
+
+func (x *GetSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{10}
+}
 
 type GetSnapshotReply struct {
-	Status           *SnapshotStatus `protobuf:"varint,1,req,name=status,enum=protobuf.SnapshotStatus" json:"status,omitempty"`
-	StatusMessage    *string         `protobuf:"bytes,2,opt,name=statusMessage" json:"statusMessage,omitempty"`
-	Timestamp        *int64          `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
-	XXX_unrecognized []byte          `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status        *SnapshotStatus `protobuf:"varint,1,req,name=status,enum=protobuf.SnapshotStatus" json:"status,omitempty"`
+	StatusMessage *string         `protobuf:"bytes,2,opt,name=statusMessage" json:"statusMessage,omitempty"`
+	Timestamp     *int64          `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
 }
 
-func (m *GetSnapshotReply) Reset()                    { *m = GetSnapshotReply{} }
-func (m *GetSnapshotReply) String() string            { return proto.CompactTextString(m) }
-func (*GetSnapshotReply) ProtoMessage()               {}
-func (*GetSnapshotReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (x *GetSnapshotReply) Reset() {
+	*x = GetSnapshotReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSnapshotReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotReply) ProtoMessage() {}
+
+func (x *GetSnapshotReply) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (m *GetSnapshotReply) GetStatus() SnapshotStatus {
-	if m != nil && m.Status != nil {
-		return *m.Status
+// Deprecated: Use GetSnapshotReply.ProtoReflect.Descriptor instead.
+func (*GetSnapshotReply) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetSnapshotReply) GetStatus() SnapshotStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
 	}
 	return SnapshotStatus_PENDING
 }
 
-func (m *GetSnapshotReply) GetStatusMessage() string {
-	if m != nil && m.StatusMessage != nil {
-		return *m.StatusMessage
+func (x *GetSnapshotReply) GetStatusMessage() string {
+	if x != nil && x.StatusMessage != nil {
+		return *x.StatusMessage
 	}
 	return ""
 }
 
-func (m *GetSnapshotReply) GetTimestamp() int64 {
-	if m != nil && m.Timestamp != nil {
-		return *m.Timestamp
+func (x *GetSnapshotReply) GetTimestamp() int64 {
+	if x != nil && x.Timestamp != nil {
+		return *x.Timestamp
 	}
 	return 0
 }
 
 type ListRequest struct {
-	Type             *SketchType `protobuf:"varint,1,req,name=type,enum=protobuf.SketchType" json:"type,omitempty"`
-	XXX_unrecognized []byte      `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type *SketchType `protobuf:"varint,1,req,name=type,enum=protobuf.SketchType" json:"type,omitempty"`
 }
 
-func (m *ListRequest) Reset()                    { *m = ListRequest{} }
-func (m *ListRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListRequest) ProtoMessage()               {}
-func (*ListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (m *ListRequest) GetType() SketchType {
-	if m != nil && m.Type != nil {
-		return *m.Type
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListRequest) GetType() SketchType {
+	if x != nil && x.Type != nil {
+		return *x.Type
 	}
 	return SketchType_MEMB
 }
 
 type ListReply struct {
-	Sketches         []*Sketch `protobuf:"bytes,1,rep,name=sketches" json:"sketches,omitempty"`
-	XXX_unrecognized []byte    `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sketches []*Sketch `protobuf:"bytes,1,rep,name=sketches" json:"sketches,omitempty"`
 }
 
-func (m *ListReply) Reset()                    { *m = ListReply{} }
-func (m *ListReply) String() string            { return proto.CompactTextString(m) }
-func (*ListReply) ProtoMessage()               {}
-func (*ListReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (x *ListReply) Reset() {
+	*x = ListReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReply) ProtoMessage() {}
 
-func (m *ListReply) GetSketches() []*Sketch {
-	if m != nil {
-		return m.Sketches
+func (x *ListReply) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReply.ProtoReflect.Descriptor instead.
+func (*ListReply) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListReply) GetSketches() []*Sketch {
+	if x != nil {
+		return x.Sketches
 	}
 	return nil
 }
 
 type ListDomainsReply struct {
-	Names            []string `protobuf:"bytes,1,rep,name=names" json:"names,omitempty"`
-	XXX_unrecognized []byte   `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names []string `protobuf:"bytes,1,rep,name=names" json:"names,omitempty"`
+}
+
+func (x *ListDomainsReply) Reset() {
+	*x = ListDomainsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDomainsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainsReply) ProtoMessage() {}
+
+func (x *ListDomainsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *ListDomainsReply) Reset()                    { *m = ListDomainsReply{} }
-func (m *ListDomainsReply) String() string            { return proto.CompactTextString(m) }
-func (*ListDomainsReply) ProtoMessage()               {}
-func (*ListDomainsReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+// Deprecated: Use ListDomainsReply.ProtoReflect.Descriptor instead.
+func (*ListDomainsReply) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{14}
+}
 
-func (m *ListDomainsReply) GetNames() []string {
-	if m != nil {
-		return m.Names
+func (x *ListDomainsReply) GetNames() []string {
+	if x != nil {
+		return x.Names
 	}
 	return nil
 }
 
 type AddRequest struct {
-	Domain           *Domain  `protobuf:"bytes,1,opt,name=domain" json:"domain,omitempty"`
-	Sketch           *Sketch  `protobuf:"bytes,2,opt,name=sketch" json:"sketch,omitempty"`
-	Values           []string `protobuf:"bytes,3,rep,name=values" json:"values,omitempty"`
-	XXX_unrecognized []byte   `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domain *Domain  `protobuf:"bytes,1,opt,name=domain" json:"domain,omitempty"`
+	Sketch *Sketch  `protobuf:"bytes,2,opt,name=sketch" json:"sketch,omitempty"`
+	Values []string `protobuf:"bytes,3,rep,name=values" json:"values,omitempty"`
 }
 
-func (m *AddRequest) Reset()                    { *m = AddRequest{} }
-func (m *AddRequest) String() string            { return proto.CompactTextString(m) }
-func (*AddRequest) ProtoMessage()               {}
-func (*AddRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (x *AddRequest) Reset() {
+	*x = AddRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRequest) ProtoMessage() {}
+
+func (x *AddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRequest.ProtoReflect.Descriptor instead.
+func (*AddRequest) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{15}
+}
 
-func (m *AddRequest) GetDomain() *Domain {
-	if m != nil {
-		return m.Domain
+func (x *AddRequest) GetDomain() *Domain {
+	if x != nil {
+		return x.Domain
 	}
 	return nil
 }
 
-func (m *AddRequest) GetSketch() *Sketch {
-	if m != nil {
-		return m.Sketch
+func (x *AddRequest) GetSketch() *Sketch {
+	if x != nil {
+		return x.Sketch
 	}
 	return nil
 }
 
-func (m *AddRequest) GetValues() []string {
-	if m != nil {
-		return m.Values
+func (x *AddRequest) GetValues() []string {
+	if x != nil {
+		return x.Values
 	}
 	return nil
 }
 
 type AddReply struct {
-	XXX_unrecognized []byte `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func (m *AddReply) Reset()                    { *m = AddReply{} }
-func (m *AddReply) String() string            { return proto.CompactTextString(m) }
-func (*AddReply) ProtoMessage()               {}
-func (*AddReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (x *AddReply) Reset() {
+	*x = AddReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReply) ProtoMessage() {}
+
+func (x *AddReply) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReply.ProtoReflect.Descriptor instead.
+func (*AddReply) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{16}
+}
 
-// All Sketches will be of one kind
-// All values will apply to all sketches (if card or ranking, values will be ignored)
 type GetRequest struct {
-	Sketches         []*Sketch `protobuf:"bytes,1,rep,name=sketches" json:"sketches,omitempty"`
-	Values           []string  `protobuf:"bytes,2,rep,name=values" json:"values,omitempty"`
-	XXX_unrecognized []byte    `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sketches []*Sketch `protobuf:"bytes,1,rep,name=sketches" json:"sketches,omitempty"`
+	Values   []string  `protobuf:"bytes,2,rep,name=values" json:"values,omitempty"`
 }
 
-func (m *GetRequest) Reset()                    { *m = GetRequest{} }
-func (m *GetRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetRequest) ProtoMessage()               {}
-func (*GetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func (m *GetRequest) GetSketches() []*Sketch {
-	if m != nil {
-		return m.Sketches
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetRequest) GetSketches() []*Sketch {
+	if x != nil {
+		return x.Sketches
 	}
 	return nil
 }
 
-func (m *GetRequest) GetValues() []string {
-	if m != nil {
-		return m.Values
+func (x *GetRequest) GetValues() []string {
+	if x != nil {
+		return x.Values
 	}
 	return nil
 }
 
 type MembershipResult struct {
-	Memberships      []*Membership `protobuf:"bytes,1,rep,name=memberships" json:"memberships,omitempty"`
-	XXX_unrecognized []byte        `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Memberships []*Membership `protobuf:"bytes,1,rep,name=memberships" json:"memberships,omitempty"`
+}
+
+func (x *MembershipResult) Reset() {
+	*x = MembershipResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MembershipResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *MembershipResult) Reset()                    { *m = MembershipResult{} }
-func (m *MembershipResult) String() string            { return proto.CompactTextString(m) }
-func (*MembershipResult) ProtoMessage()               {}
-func (*MembershipResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*MembershipResult) ProtoMessage() {}
 
-func (m *MembershipResult) GetMemberships() []*Membership {
-	if m != nil {
-		return m.Memberships
+func (x *MembershipResult) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MembershipResult.ProtoReflect.Descriptor instead.
+func (*MembershipResult) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MembershipResult) GetMemberships() []*Membership {
+	if x != nil {
+		return x.Memberships
 	}
 	return nil
 }
 
 type FrequencyResult struct {
-	Frequencies      []*Frequency `protobuf:"bytes,2,rep,name=frequencies" json:"frequencies,omitempty"`
-	XXX_unrecognized []byte       `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Frequencies []*Frequency `protobuf:"bytes,2,rep,name=frequencies" json:"frequencies,omitempty"`
+}
+
+func (x *FrequencyResult) Reset() {
+	*x = FrequencyResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FrequencyResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *FrequencyResult) Reset()                    { *m = FrequencyResult{} }
-func (m *FrequencyResult) String() string            { return proto.CompactTextString(m) }
-func (*FrequencyResult) ProtoMessage()               {}
-func (*FrequencyResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*FrequencyResult) ProtoMessage() {}
 
-func (m *FrequencyResult) GetFrequencies() []*Frequency {
-	if m != nil {
-		return m.Frequencies
+func (x *FrequencyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrequencyResult.ProtoReflect.Descriptor instead.
+func (*FrequencyResult) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FrequencyResult) GetFrequencies() []*Frequency {
+	if x != nil {
+		return x.Frequencies
 	}
 	return nil
 }
 
 type CardinalityResult struct {
-	Cardinality      *int64 `protobuf:"varint,1,req,name=cardinality" json:"cardinality,omitempty"`
-	XXX_unrecognized []byte `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cardinality *int64 `protobuf:"varint,1,req,name=cardinality" json:"cardinality,omitempty"`
 }
 
-func (m *CardinalityResult) Reset()                    { *m = CardinalityResult{} }
-func (m *CardinalityResult) String() string            { return proto.CompactTextString(m) }
-func (*CardinalityResult) ProtoMessage()               {}
-func (*CardinalityResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (x *CardinalityResult) Reset() {
+	*x = CardinalityResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CardinalityResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardinalityResult) ProtoMessage() {}
+
+func (x *CardinalityResult) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardinalityResult.ProtoReflect.Descriptor instead.
+func (*CardinalityResult) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{20}
+}
 
-func (m *CardinalityResult) GetCardinality() int64 {
-	if m != nil && m.Cardinality != nil {
-		return *m.Cardinality
+func (x *CardinalityResult) GetCardinality() int64 {
+	if x != nil && x.Cardinality != nil {
+		return *x.Cardinality
 	}
 	return 0
 }
 
 type RankingsResult struct {
-	Rankings         []*Rank `protobuf:"bytes,1,rep,name=rankings" json:"rankings,omitempty"`
-	XXX_unrecognized []byte  `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rankings []*Rank `protobuf:"bytes,1,rep,name=rankings" json:"rankings,omitempty"`
+}
+
+func (x *RankingsResult) Reset() {
+	*x = RankingsResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RankingsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankingsResult) ProtoMessage() {}
+
+func (x *RankingsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *RankingsResult) Reset()                    { *m = RankingsResult{} }
-func (m *RankingsResult) String() string            { return proto.CompactTextString(m) }
-func (*RankingsResult) ProtoMessage()               {}
-func (*RankingsResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+// Deprecated: Use RankingsResult.ProtoReflect.Descriptor instead.
+func (*RankingsResult) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{21}
+}
 
-func (m *RankingsResult) GetRankings() []*Rank {
-	if m != nil {
-		return m.Rankings
+func (x *RankingsResult) GetRankings() []*Rank {
+	if x != nil {
+		return x.Rankings
 	}
 	return nil
 }
 
 type GetMembershipReply struct {
-	Results          []*MembershipResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
-	XXX_unrecognized []byte              `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*MembershipResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
 }
 
-func (m *GetMembershipReply) Reset()                    { *m = GetMembershipReply{} }
-func (m *GetMembershipReply) String() string            { return proto.CompactTextString(m) }
-func (*GetMembershipReply) ProtoMessage()               {}
-func (*GetMembershipReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (x *GetMembershipReply) Reset() {
+	*x = GetMembershipReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func (m *GetMembershipReply) GetResults() []*MembershipResult {
-	if m != nil {
-		return m.Results
+func (x *GetMembershipReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMembershipReply) ProtoMessage() {}
+
+func (x *GetMembershipReply) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMembershipReply.ProtoReflect.Descriptor instead.
+func (*GetMembershipReply) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetMembershipReply) GetResults() []*MembershipResult {
+	if x != nil {
+		return x.Results
 	}
 	return nil
 }
 
 type GetFrequencyReply struct {
-	Results          []*FrequencyResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
-	XXX_unrecognized []byte             `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*FrequencyResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (x *GetFrequencyReply) Reset() {
+	*x = GetFrequencyReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (m *GetFrequencyReply) Reset()                    { *m = GetFrequencyReply{} }
-func (m *GetFrequencyReply) String() string            { return proto.CompactTextString(m) }
-func (*GetFrequencyReply) ProtoMessage()               {}
-func (*GetFrequencyReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (x *GetFrequencyReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (m *GetFrequencyReply) GetResults() []*FrequencyResult {
-	if m != nil {
-		return m.Results
+func (*GetFrequencyReply) ProtoMessage() {}
+
+func (x *GetFrequencyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFrequencyReply.ProtoReflect.Descriptor instead.
+func (*GetFrequencyReply) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetFrequencyReply) GetResults() []*FrequencyResult {
+	if x != nil {
+		return x.Results
 	}
 	return nil
 }
 
 type GetCardinalityReply struct {
-	Results          []*CardinalityResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
-	XXX_unrecognized []byte               `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*CardinalityResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (x *GetCardinalityReply) Reset() {
+	*x = GetCardinalityReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCardinalityReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardinalityReply) ProtoMessage() {}
+
+func (x *GetCardinalityReply) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *GetCardinalityReply) Reset()                    { *m = GetCardinalityReply{} }
-func (m *GetCardinalityReply) String() string            { return proto.CompactTextString(m) }
-func (*GetCardinalityReply) ProtoMessage()               {}
-func (*GetCardinalityReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+// Deprecated: Use GetCardinalityReply.ProtoReflect.Descriptor instead.
+func (*GetCardinalityReply) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{24}
+}
 
-func (m *GetCardinalityReply) GetResults() []*CardinalityResult {
-	if m != nil {
-		return m.Results
+func (x *GetCardinalityReply) GetResults() []*CardinalityResult {
+	if x != nil {
+		return x.Results
 	}
 	return nil
 }
 
 type GetRankingsReply struct {
-	Results          []*RankingsResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
-	XXX_unrecognized []byte            `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*RankingsResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (x *GetRankingsReply) Reset() {
+	*x = GetRankingsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_skizze_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRankingsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRankingsReply) ProtoMessage() {}
+
+func (x *GetRankingsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_skizze_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *GetRankingsReply) Reset()                    { *m = GetRankingsReply{} }
-func (m *GetRankingsReply) String() string            { return proto.CompactTextString(m) }
-func (*GetRankingsReply) ProtoMessage()               {}
-func (*GetRankingsReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+// Deprecated: Use GetRankingsReply.ProtoReflect.Descriptor instead.
+func (*GetRankingsReply) Descriptor() ([]byte, []int) {
+	return file_skizze_proto_rawDescGZIP(), []int{25}
+}
 
-func (m *GetRankingsReply) GetResults() []*RankingsResult {
-	if m != nil {
-		return m.Results
+func (x *GetRankingsReply) GetResults() []*RankingsResult {
+	if x != nil {
+		return x.Results
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Empty)(nil), "protobuf.Empty")
-	proto.RegisterType((*SketchProperties)(nil), "protobuf.SketchProperties")
-	proto.RegisterType((*SketchState)(nil), "protobuf.SketchState")
-	proto.RegisterType((*Domain)(nil), "protobuf.Domain")
-	proto.RegisterType((*Sketch)(nil), "protobuf.Sketch")
-	proto.RegisterType((*Membership)(nil), "protobuf.Membership")
-	proto.RegisterType((*Frequency)(nil), "protobuf.Frequency")
-	proto.RegisterType((*Rank)(nil), "protobuf.Rank")
-	proto.RegisterType((*CreateSnapshotRequest)(nil), "protobuf.CreateSnapshotRequest")
-	proto.RegisterType((*CreateSnapshotReply)(nil), "protobuf.CreateSnapshotReply")
-	proto.RegisterType((*GetSnapshotRequest)(nil), "protobuf.GetSnapshotRequest")
-	proto.RegisterType((*GetSnapshotReply)(nil), "protobuf.GetSnapshotReply")
-	proto.RegisterType((*ListRequest)(nil), "protobuf.ListRequest")
-	proto.RegisterType((*ListReply)(nil), "protobuf.ListReply")
-	proto.RegisterType((*ListDomainsReply)(nil), "protobuf.ListDomainsReply")
-	proto.RegisterType((*AddRequest)(nil), "protobuf.AddRequest")
-	proto.RegisterType((*AddReply)(nil), "protobuf.AddReply")
-	proto.RegisterType((*GetRequest)(nil), "protobuf.GetRequest")
-	proto.RegisterType((*MembershipResult)(nil), "protobuf.MembershipResult")
-	proto.RegisterType((*FrequencyResult)(nil), "protobuf.FrequencyResult")
-	proto.RegisterType((*CardinalityResult)(nil), "protobuf.CardinalityResult")
-	proto.RegisterType((*RankingsResult)(nil), "protobuf.RankingsResult")
-	proto.RegisterType((*GetMembershipReply)(nil), "protobuf.GetMembershipReply")
-	proto.RegisterType((*GetFrequencyReply)(nil), "protobuf.GetFrequencyReply")
-	proto.RegisterType((*GetCardinalityReply)(nil), "protobuf.GetCardinalityReply")
-	proto.RegisterType((*GetRankingsReply)(nil), "protobuf.GetRankingsReply")
-	proto.RegisterEnum("protobuf.SketchType", SketchType_name, SketchType_value)
-	proto.RegisterEnum("protobuf.SnapshotStatus", SnapshotStatus_name, SnapshotStatus_value)
+var File_skizze_proto protoreflect.FileDescriptor
+
+var file_skizze_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x73, 0x6b, 0x69, 0x7a, 0x7a, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x6c, 0x0a, 0x10, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x70, 0x65,
+	0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x55, 0x6e, 0x69, 0x71,
+	0x75, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6d,
+	0x61, 0x78, 0x55, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22,
+	0x4d, 0x0a, 0x0b, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x66, 0x69, 0x6c, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x08, 0x66, 0x69, 0x6c, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61,
+	0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x4a,
+	0x0a, 0x06, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x08,
+	0x73, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68,
+	0x52, 0x08, 0x73, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0xaf, 0x01, 0x0a, 0x06, 0x53,
+	0x6b, 0x65, 0x74, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x02, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
+	0x69, 0x65, 0x73, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12,
+	0x2b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x3e, 0x0a, 0x0a,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x69, 0x73, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x02,
+	0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x37, 0x0a, 0x09,
+	0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x02, 0x28, 0x03, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x32, 0x0a, 0x04, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x02,
+	0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x6d, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x02, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x88, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x30, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x02, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x24,
+	0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x22, 0x37, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x0e, 0x32,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x6b, 0x65, 0x74, 0x63,
+	0x68, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x39, 0x0a, 0x09, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2c, 0x0a, 0x08, 0x73, 0x6b, 0x65, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x52, 0x08, 0x73, 0x6b,
+	0x65, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0x28, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x22, 0x78, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28,
+	0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x28, 0x0a, 0x06, 0x73, 0x6b, 0x65, 0x74,
+	0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x52, 0x06, 0x73, 0x6b, 0x65, 0x74,
+	0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x0a, 0x0a, 0x08, 0x41, 0x64,
+	0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x52, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x73, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x52, 0x08, 0x73, 0x6b, 0x65, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x4a, 0x0a, 0x10, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x36,
+	0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x73, 0x22, 0x48, 0x0a, 0x0f, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x35, 0x0a, 0x0b, 0x66, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x79, 0x52, 0x0b, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73,
+	0x22, 0x35, 0x0a, 0x11, 0x43, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61,
+	0x6c, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x02, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x3c, 0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x6b, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2a, 0x0a, 0x08, 0x72, 0x61, 0x6e,
+	0x6b, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x08, 0x72, 0x61, 0x6e,
+	0x6b, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x4a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68,
+	0x69, 0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x22, 0x48, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x4c, 0x0a, 0x13, 0x47,
+	0x65, 0x74, 0x43, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x43,
+	0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x46, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x32, 0x0a,
+	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x2a, 0x34, 0x0a, 0x0a, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x08, 0x0a, 0x04, 0x4d, 0x45, 0x4d, 0x42, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x52, 0x45,
+	0x51, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x41, 0x4e, 0x4b, 0x10, 0x03, 0x12, 0x08, 0x0a,
+	0x04, 0x43, 0x41, 0x52, 0x44, 0x10, 0x04, 0x2a, 0x4a, 0x0a, 0x0e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e,
+	0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x5f, 0x50, 0x52, 0x4f,
+	0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x55, 0x43, 0x43, 0x45,
+	0x53, 0x53, 0x46, 0x55, 0x4c, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45,
+	0x44, 0x10, 0x04, 0x32, 0xd5, 0x07, 0x0a, 0x06, 0x53, 0x6b, 0x69, 0x7a, 0x7a, 0x65, 0x12, 0x52,
+	0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x49, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x34, 0x0a,
+	0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x07, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x12, 0x0f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x0c, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x1a, 0x0f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
+	0x31, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x10, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x1a, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x22, 0x00, 0x12, 0x34, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6b, 0x65, 0x74,
+	0x63, 0x68, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x6b,
+	0x65, 0x74, 0x63, 0x68, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x31, 0x0a,
+	0x09, 0x47, 0x65, 0x74, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x1a, 0x10, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x22, 0x00,
+	0x12, 0x31, 0x0a, 0x03, 0x41, 0x64, 0x64, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73,
+	0x68, 0x69, 0x70, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x47, 0x65,
+	0x74, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x47, 0x65, 0x74, 0x46,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x47, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52,
+	0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x6b,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x30, 0x5a, 0x2e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6b, 0x69, 0x7a, 0x7a, 0x65,
+	0x68, 0x71, 0x2f, 0x67, 0x6f, 0x73, 0x6b, 0x69, 0x7a, 0x7a, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+}
+
+var (
+	file_skizze_proto_rawDescOnce sync.Once
+	file_skizze_proto_rawDescData = file_skizze_proto_rawDesc
+)
+
+func file_skizze_proto_rawDescGZIP() []byte {
+	file_skizze_proto_rawDescOnce.Do(func() {
+		file_skizze_proto_rawDescData = protoimpl.X.CompressGZIP(file_skizze_proto_rawDescData)
+	})
+	return file_skizze_proto_rawDescData
+}
+
+var file_skizze_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_skizze_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_skizze_proto_goTypes = []interface{}{
+	(SketchType)(0),               // 0: protobuf.SketchType
+	(SnapshotStatus)(0),           // 1: protobuf.SnapshotStatus
+	(*Empty)(nil),                 // 2: protobuf.Empty
+	(*SketchProperties)(nil),      // 3: protobuf.SketchProperties
+	(*SketchState)(nil),           // 4: protobuf.SketchState
+	(*Domain)(nil),                // 5: protobuf.Domain
+	(*Sketch)(nil),                // 6: protobuf.Sketch
+	(*Membership)(nil),            // 7: protobuf.Membership
+	(*Frequency)(nil),             // 8: protobuf.Frequency
+	(*Rank)(nil),                  // 9: protobuf.Rank
+	(*CreateSnapshotRequest)(nil), // 10: protobuf.CreateSnapshotRequest
+	(*CreateSnapshotReply)(nil),   // 11: protobuf.CreateSnapshotReply
+	(*GetSnapshotRequest)(nil),    // 12: protobuf.GetSnapshotRequest
+	(*GetSnapshotReply)(nil),      // 13: protobuf.GetSnapshotReply
+	(*ListRequest)(nil),           // 14: protobuf.ListRequest
+	(*ListReply)(nil),             // 15: protobuf.ListReply
+	(*ListDomainsReply)(nil),      // 16: protobuf.ListDomainsReply
+	(*AddRequest)(nil),            // 17: protobuf.AddRequest
+	(*AddReply)(nil),              // 18: protobuf.AddReply
+	(*GetRequest)(nil),            // 19: protobuf.GetRequest
+	(*MembershipResult)(nil),      // 20: protobuf.MembershipResult
+	(*FrequencyResult)(nil),       // 21: protobuf.FrequencyResult
+	(*CardinalityResult)(nil),     // 22: protobuf.CardinalityResult
+	(*RankingsResult)(nil),        // 23: protobuf.RankingsResult
+	(*GetMembershipReply)(nil),    // 24: protobuf.GetMembershipReply
+	(*GetFrequencyReply)(nil),     // 25: protobuf.GetFrequencyReply
+	(*GetCardinalityReply)(nil),   // 26: protobuf.GetCardinalityReply
+	(*GetRankingsReply)(nil),      // 27: protobuf.GetRankingsReply
+}
+var file_skizze_proto_depIdxs = []int32{
+	6,  // 0: protobuf.Domain.sketches:type_name -> protobuf.Sketch
+	0,  // 1: protobuf.Sketch.type:type_name -> protobuf.SketchType
+	3,  // 2: protobuf.Sketch.properties:type_name -> protobuf.SketchProperties
+	4,  // 3: protobuf.Sketch.state:type_name -> protobuf.SketchState
+	1,  // 4: protobuf.CreateSnapshotReply.status:type_name -> protobuf.SnapshotStatus
+	1,  // 5: protobuf.GetSnapshotReply.status:type_name -> protobuf.SnapshotStatus
+	0,  // 6: protobuf.ListRequest.type:type_name -> protobuf.SketchType
+	6,  // 7: protobuf.ListReply.sketches:type_name -> protobuf.Sketch
+	5,  // 8: protobuf.AddRequest.domain:type_name -> protobuf.Domain
+	6,  // 9: protobuf.AddRequest.sketch:type_name -> protobuf.Sketch
+	6,  // 10: protobuf.GetRequest.sketches:type_name -> protobuf.Sketch
+	7,  // 11: protobuf.MembershipResult.memberships:type_name -> protobuf.Membership
+	8,  // 12: protobuf.FrequencyResult.frequencies:type_name -> protobuf.Frequency
+	9,  // 13: protobuf.RankingsResult.rankings:type_name -> protobuf.Rank
+	20, // 14: protobuf.GetMembershipReply.results:type_name -> protobuf.MembershipResult
+	21, // 15: protobuf.GetFrequencyReply.results:type_name -> protobuf.FrequencyResult
+	22, // 16: protobuf.GetCardinalityReply.results:type_name -> protobuf.CardinalityResult
+	23, // 17: protobuf.GetRankingsReply.results:type_name -> protobuf.RankingsResult
+	10, // 18: protobuf.Skizze.CreateSnapshot:input_type -> protobuf.CreateSnapshotRequest
+	12, // 19: protobuf.Skizze.GetSnapshot:input_type -> protobuf.GetSnapshotRequest
+	14, // 20: protobuf.Skizze.List:input_type -> protobuf.ListRequest
+	2,  // 21: protobuf.Skizze.ListAll:input_type -> protobuf.Empty
+	2,  // 22: protobuf.Skizze.ListDomains:input_type -> protobuf.Empty
+	5,  // 23: protobuf.Skizze.CreateDomain:input_type -> protobuf.Domain
+	5,  // 24: protobuf.Skizze.DeleteDomain:input_type -> protobuf.Domain
+	5,  // 25: protobuf.Skizze.GetDomain:input_type -> protobuf.Domain
+	6,  // 26: protobuf.Skizze.CreateSketch:input_type -> protobuf.Sketch
+	6,  // 27: protobuf.Skizze.DeleteSketch:input_type -> protobuf.Sketch
+	6,  // 28: protobuf.Skizze.GetSketch:input_type -> protobuf.Sketch
+	17, // 29: protobuf.Skizze.Add:input_type -> protobuf.AddRequest
+	19, // 30: protobuf.Skizze.GetMembership:input_type -> protobuf.GetRequest
+	19, // 31: protobuf.Skizze.GetFrequency:input_type -> protobuf.GetRequest
+	19, // 32: protobuf.Skizze.GetCardinality:input_type -> protobuf.GetRequest
+	19, // 33: protobuf.Skizze.GetRankings:input_type -> protobuf.GetRequest
+	11, // 34: protobuf.Skizze.CreateSnapshot:output_type -> protobuf.CreateSnapshotReply
+	13, // 35: protobuf.Skizze.GetSnapshot:output_type -> protobuf.GetSnapshotReply
+	15, // 36: protobuf.Skizze.List:output_type -> protobuf.ListReply
+	15, // 37: protobuf.Skizze.ListAll:output_type -> protobuf.ListReply
+	16, // 38: protobuf.Skizze.ListDomains:output_type -> protobuf.ListDomainsReply
+	5,  // 39: protobuf.Skizze.CreateDomain:output_type -> protobuf.Domain
+	2,  // 40: protobuf.Skizze.DeleteDomain:output_type -> protobuf.Empty
+	5,  // 41: protobuf.Skizze.GetDomain:output_type -> protobuf.Domain
+	6,  // 42: protobuf.Skizze.CreateSketch:output_type -> protobuf.Sketch
+	2,  // 43: protobuf.Skizze.DeleteSketch:output_type -> protobuf.Empty
+	6,  // 44: protobuf.Skizze.GetSketch:output_type -> protobuf.Sketch
+	18, // 45: protobuf.Skizze.Add:output_type -> protobuf.AddReply
+	24, // 46: protobuf.Skizze.GetMembership:output_type -> protobuf.GetMembershipReply
+	25, // 47: protobuf.Skizze.GetFrequency:output_type -> protobuf.GetFrequencyReply
+	26, // 48: protobuf.Skizze.GetCardinality:output_type -> protobuf.GetCardinalityReply
+	27, // 49: protobuf.Skizze.GetRankings:output_type -> protobuf.GetRankingsReply
+	34, // [34:50] is the sub-list for method output_type
+	18, // [18:34] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_skizze_proto_init() }
+func file_skizze_proto_init() {
+	if File_skizze_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_skizze_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SketchProperties); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SketchState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Domain); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sketch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Membership); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Frequency); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rank); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSnapshotReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSnapshotReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDomainsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MembershipResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FrequencyResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CardinalityResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RankingsResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMembershipReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFrequencyReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCardinalityReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_skizze_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRankingsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_skizze_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_skizze_proto_goTypes,
+		DependencyIndexes: file_skizze_proto_depIdxs,
+		EnumInfos:         file_skizze_proto_enumTypes,
+		MessageInfos:      file_skizze_proto_msgTypes,
+	}.Build()
+	File_skizze_proto = out.File
+	file_skizze_proto_rawDesc = nil
+	file_skizze_proto_goTypes = nil
+	file_skizze_proto_depIdxs = nil
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
-var _ grpc.ClientConn
+var _ grpc.ClientConnInterface
 
-// Client API for Skizze service
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
 
+// SkizzeClient is the client API for Skizze service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type SkizzeClient interface {
 	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotReply, error)
 	GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*GetSnapshotReply, error)
@@ -748,16 +2131,16 @@ type SkizzeClient interface {
 }
 
 type skizzeClient struct {
-	cc *grpc.ClientConn
+	cc grpc.ClientConnInterface
 }
 
-func NewSkizzeClient(cc *grpc.ClientConn) SkizzeClient {
+func NewSkizzeClient(cc grpc.ClientConnInterface) SkizzeClient {
 	return &skizzeClient{cc}
 }
 
 func (c *skizzeClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotReply, error) {
 	out := new(CreateSnapshotReply)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/CreateSnapshot", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/CreateSnapshot", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -766,7 +2149,7 @@ func (c *skizzeClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotReq
 
 func (c *skizzeClient) GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*GetSnapshotReply, error) {
 	out := new(GetSnapshotReply)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/GetSnapshot", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/GetSnapshot", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -775,7 +2158,7 @@ func (c *skizzeClient) GetSnapshot(ctx context.Context, in *GetSnapshotRequest,
 
 func (c *skizzeClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListReply, error) {
 	out := new(ListReply)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/List", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/List", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -784,7 +2167,7 @@ func (c *skizzeClient) List(ctx context.Context, in *ListRequest, opts ...grpc.C
 
 func (c *skizzeClient) ListAll(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListReply, error) {
 	out := new(ListReply)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/ListAll", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/ListAll", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -793,7 +2176,7 @@ func (c *skizzeClient) ListAll(ctx context.Context, in *Empty, opts ...grpc.Call
 
 func (c *skizzeClient) ListDomains(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListDomainsReply, error) {
 	out := new(ListDomainsReply)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/ListDomains", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/ListDomains", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -802,7 +2185,7 @@ func (c *skizzeClient) ListDomains(ctx context.Context, in *Empty, opts ...grpc.
 
 func (c *skizzeClient) CreateDomain(ctx context.Context, in *Domain, opts ...grpc.CallOption) (*Domain, error) {
 	out := new(Domain)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/CreateDomain", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/CreateDomain", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -811,7 +2194,7 @@ func (c *skizzeClient) CreateDomain(ctx context.Context, in *Domain, opts ...grp
 
 func (c *skizzeClient) DeleteDomain(ctx context.Context, in *Domain, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/DeleteDomain", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/DeleteDomain", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -820,7 +2203,7 @@ func (c *skizzeClient) DeleteDomain(ctx context.Context, in *Domain, opts ...grp
 
 func (c *skizzeClient) GetDomain(ctx context.Context, in *Domain, opts ...grpc.CallOption) (*Domain, error) {
 	out := new(Domain)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/GetDomain", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/GetDomain", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -829,7 +2212,7 @@ func (c *skizzeClient) GetDomain(ctx context.Context, in *Domain, opts ...grpc.C
 
 func (c *skizzeClient) CreateSketch(ctx context.Context, in *Sketch, opts ...grpc.CallOption) (*Sketch, error) {
 	out := new(Sketch)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/CreateSketch", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/CreateSketch", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -838,7 +2221,7 @@ func (c *skizzeClient) CreateSketch(ctx context.Context, in *Sketch, opts ...grp
 
 func (c *skizzeClient) DeleteSketch(ctx context.Context, in *Sketch, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/DeleteSketch", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/DeleteSketch", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -847,7 +2230,7 @@ func (c *skizzeClient) DeleteSketch(ctx context.Context, in *Sketch, opts ...grp
 
 func (c *skizzeClient) GetSketch(ctx context.Context, in *Sketch, opts ...grpc.CallOption) (*Sketch, error) {
 	out := new(Sketch)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/GetSketch", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/GetSketch", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -856,7 +2239,7 @@ func (c *skizzeClient) GetSketch(ctx context.Context, in *Sketch, opts ...grpc.C
 
 func (c *skizzeClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddReply, error) {
 	out := new(AddReply)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/Add", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/Add", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -865,7 +2248,7 @@ func (c *skizzeClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.Cal
 
 func (c *skizzeClient) GetMembership(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetMembershipReply, error) {
 	out := new(GetMembershipReply)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/GetMembership", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/GetMembership", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -874,7 +2257,7 @@ func (c *skizzeClient) GetMembership(ctx context.Context, in *GetRequest, opts .
 
 func (c *skizzeClient) GetFrequency(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetFrequencyReply, error) {
 	out := new(GetFrequencyReply)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/GetFrequency", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/GetFrequency", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -883,7 +2266,7 @@ func (c *skizzeClient) GetFrequency(ctx context.Context, in *GetRequest, opts ..
 
 func (c *skizzeClient) GetCardinality(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetCardinalityReply, error) {
 	out := new(GetCardinalityReply)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/GetCardinality", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/GetCardinality", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -892,15 +2275,14 @@ func (c *skizzeClient) GetCardinality(ctx context.Context, in *GetRequest, opts
 
 func (c *skizzeClient) GetRankings(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetRankingsReply, error) {
 	out := new(GetRankingsReply)
-	err := grpc.Invoke(ctx, "/protobuf.Skizze/GetRankings", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/protobuf.Skizze/GetRankings", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// Server API for Skizze service
-
+// SkizzeServer is the server API for Skizze service.
 type SkizzeServer interface {
 	CreateSnapshot(context.Context, *CreateSnapshotRequest) (*CreateSnapshotReply, error)
 	GetSnapshot(context.Context, *GetSnapshotRequest) (*GetSnapshotReply, error)
@@ -920,200 +2302,349 @@ type SkizzeServer interface {
 	GetRankings(context.Context, *GetRequest) (*GetRankingsReply, error)
 }
 
+// UnimplementedSkizzeServer can be embedded to have forward compatible implementations.
+type UnimplementedSkizzeServer struct {
+}
+
+func (*UnimplementedSkizzeServer) CreateSnapshot(context.Context, *CreateSnapshotRequest) (*CreateSnapshotReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSnapshot not implemented")
+}
+func (*UnimplementedSkizzeServer) GetSnapshot(context.Context, *GetSnapshotRequest) (*GetSnapshotReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshot not implemented")
+}
+func (*UnimplementedSkizzeServer) List(context.Context, *ListRequest) (*ListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedSkizzeServer) ListAll(context.Context, *Empty) (*ListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAll not implemented")
+}
+func (*UnimplementedSkizzeServer) ListDomains(context.Context, *Empty) (*ListDomainsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDomains not implemented")
+}
+func (*UnimplementedSkizzeServer) CreateDomain(context.Context, *Domain) (*Domain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDomain not implemented")
+}
+func (*UnimplementedSkizzeServer) DeleteDomain(context.Context, *Domain) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDomain not implemented")
+}
+func (*UnimplementedSkizzeServer) GetDomain(context.Context, *Domain) (*Domain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDomain not implemented")
+}
+func (*UnimplementedSkizzeServer) CreateSketch(context.Context, *Sketch) (*Sketch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSketch not implemented")
+}
+func (*UnimplementedSkizzeServer) DeleteSketch(context.Context, *Sketch) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSketch not implemented")
+}
+func (*UnimplementedSkizzeServer) GetSketch(context.Context, *Sketch) (*Sketch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSketch not implemented")
+}
+func (*UnimplementedSkizzeServer) Add(context.Context, *AddRequest) (*AddReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
+}
+func (*UnimplementedSkizzeServer) GetMembership(context.Context, *GetRequest) (*GetMembershipReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMembership not implemented")
+}
+func (*UnimplementedSkizzeServer) GetFrequency(context.Context, *GetRequest) (*GetFrequencyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFrequency not implemented")
+}
+func (*UnimplementedSkizzeServer) GetCardinality(context.Context, *GetRequest) (*GetCardinalityReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCardinality not implemented")
+}
+func (*UnimplementedSkizzeServer) GetRankings(context.Context, *GetRequest) (*GetRankingsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRankings not implemented")
+}
+
 func RegisterSkizzeServer(s *grpc.Server, srv SkizzeServer) {
 	s.RegisterService(&_Skizze_serviceDesc, srv)
 }
 
-func _Skizze_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateSnapshotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).CreateSnapshot(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).CreateSnapshot(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/CreateSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).CreateSnapshot(ctx, req.(*CreateSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetSnapshotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).GetSnapshot(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).GetSnapshot(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/GetSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).GetSnapshot(ctx, req.(*GetSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).List(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).List(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_ListAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_ListAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).ListAll(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).ListAll(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/ListAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).ListAll(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_ListDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_ListDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).ListDomains(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).ListDomains(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/ListDomains",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).ListDomains(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_CreateDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_CreateDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Domain)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).CreateDomain(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).CreateDomain(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/CreateDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).CreateDomain(ctx, req.(*Domain))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_DeleteDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_DeleteDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Domain)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).DeleteDomain(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).DeleteDomain(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/DeleteDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).DeleteDomain(ctx, req.(*Domain))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_GetDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_GetDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Domain)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).GetDomain(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).GetDomain(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/GetDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).GetDomain(ctx, req.(*Domain))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_CreateSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_CreateSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Sketch)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).CreateSketch(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).CreateSketch(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/CreateSketch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).CreateSketch(ctx, req.(*Sketch))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_DeleteSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_DeleteSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Sketch)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).DeleteSketch(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).DeleteSketch(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/DeleteSketch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).DeleteSketch(ctx, req.(*Sketch))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_GetSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_GetSketch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Sketch)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).GetSketch(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).GetSketch(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/GetSketch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).GetSketch(ctx, req.(*Sketch))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).Add(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).Add(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).Add(ctx, req.(*AddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_GetMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_GetMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).GetMembership(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).GetMembership(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/GetMembership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).GetMembership(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_GetFrequency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_GetFrequency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).GetFrequency(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).GetFrequency(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/GetFrequency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).GetFrequency(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_GetCardinality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_GetCardinality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).GetCardinality(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).GetCardinality(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/GetCardinality",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).GetCardinality(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Skizze_GetRankings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+func _Skizze_GetRankings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(SkizzeServer).GetRankings(ctx, in)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(SkizzeServer).GetRankings(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.Skizze/GetRankings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkizzeServer).GetRankings(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 var _Skizze_serviceDesc = grpc.ServiceDesc{
@@ -1185,74 +2716,6 @@ var _Skizze_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Skizze_GetRankings_Handler,
 		},
 	},
-	Streams: []grpc.StreamDesc{},
-}
-
-var fileDescriptor0 = []byte{
-	// 1037 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x56, 0xed, 0x72, 0xdb, 0x44,
-	0x14, 0xad, 0x3f, 0xe2, 0x8f, 0xab, 0xd4, 0x71, 0x37, 0x29, 0x18, 0xb5, 0x1d, 0x3a, 0x0b, 0xc3,
-	0x78, 0x02, 0x93, 0x50, 0x37, 0x81, 0x81, 0xe9, 0x30, 0x63, 0x1c, 0x3b, 0x38, 0xc4, 0x21, 0xac,
-	0xc9, 0x6f, 0x46, 0xb5, 0x37, 0x8d, 0x26, 0x92, 0x6d, 0xb4, 0x32, 0xd3, 0xe4, 0x09, 0x78, 0x1b,
-	0x9e, 0x86, 0xf7, 0x61, 0x3f, 0x24, 0xed, 0xae, 0x6c, 0xd3, 0xc9, 0x0f, 0xfe, 0x69, 0xef, 0xde,
-	0x73, 0xee, 0xd5, 0xd9, 0xbd, 0x47, 0x82, 0xcf, 0x58, 0x34, 0x39, 0x9c, 0x7a, 0xb1, 0x17, 0xce,
-	0xa7, 0x34, 0x38, 0x5c, 0x44, 0xf3, 0x78, 0xfe, 0x76, 0x79, 0x7d, 0xc8, 0x6e, 0xfd, 0xfb, 0x7b,
-	0x7a, 0x20, 0xd7, 0xa8, 0x96, 0x86, 0x71, 0x15, 0xb6, 0xfa, 0xe1, 0x22, 0xbe, 0xc3, 0x01, 0x34,
-	0xc7, 0xb7, 0x34, 0x9e, 0xdc, 0x5c, 0x46, 0xf3, 0x05, 0x8d, 0x62, 0x9f, 0x32, 0xf4, 0x05, 0x34,
-	0x42, 0xef, 0xfd, 0xd5, 0xcc, 0xff, 0x63, 0x49, 0x87, 0x31, 0x0d, 0x59, 0xab, 0xf0, 0xb2, 0xd0,
-	0x2e, 0x91, 0x5c, 0x14, 0x3d, 0x87, 0x3a, 0x8d, 0xa2, 0x79, 0x44, 0xbc, 0x98, 0xb6, 0x8a, 0x3c,
-	0xa5, 0x48, 0x74, 0x00, 0x21, 0x28, 0x33, 0xff, 0x9e, 0xb6, 0x4a, 0x12, 0x2b, 0x9f, 0xf1, 0x08,
-	0x1c, 0x55, 0x6d, 0x1c, 0x8b, 0x14, 0x17, 0x6a, 0xd7, 0x7e, 0x10, 0x48, 0x7c, 0x41, 0xe2, 0xb3,
-	0x35, 0xc2, 0xb0, 0x1d, 0x78, 0x2c, 0x1e, 0xcf, 0xbc, 0x05, 0xbb, 0x99, 0xc7, 0x92, 0xbf, 0x44,
-	0xac, 0x18, 0x3e, 0x83, 0xca, 0xc9, 0x3c, 0xf4, 0xfc, 0x99, 0x28, 0x36, 0xf3, 0x42, 0xc1, 0x52,
-	0x6c, 0xd7, 0x89, 0x7c, 0x46, 0x5f, 0x41, 0x8d, 0xc9, 0x62, 0x94, 0x71, 0x74, 0xa9, 0xed, 0x74,
-	0x9a, 0x07, 0xa9, 0x00, 0x07, 0xaa, 0x0d, 0x92, 0x65, 0xe0, 0xbf, 0x0b, 0x50, 0x51, 0xc1, 0xb5,
-	0x64, 0x6d, 0x28, 0xc7, 0x77, 0x0b, 0xf1, 0x9a, 0xc5, 0x76, 0xa3, 0xb3, 0x97, 0x27, 0xfa, 0x8d,
-	0xef, 0x11, 0x99, 0x81, 0xbe, 0x07, 0x58, 0x64, 0x5a, 0xca, 0xb7, 0x77, 0x3a, 0x6e, 0x3e, 0x5f,
-	0xab, 0x4d, 0x8c, 0x6c, 0xf4, 0x25, 0x6c, 0x31, 0xa1, 0x4c, 0xab, 0x2c, 0x61, 0x4f, 0xf3, 0x30,
-	0x29, 0x1b, 0x51, 0x39, 0xf8, 0x07, 0x80, 0x11, 0x0d, 0xdf, 0xd2, 0x88, 0xdd, 0xf8, 0x0b, 0xb4,
-	0x07, 0x5b, 0x7f, 0x7a, 0xc1, 0x32, 0xed, 0x5a, 0x2d, 0x84, 0xc2, 0x3e, 0x53, 0x59, 0xb2, 0xf5,
-	0x1a, 0xc9, 0xd6, 0xf8, 0x5b, 0xa8, 0x0f, 0x22, 0xca, 0x4f, 0x73, 0x36, 0xb9, 0xdb, 0x00, 0xe7,
-	0xd1, 0xc9, 0x7c, 0x39, 0x8b, 0x25, 0xb6, 0x44, 0xd4, 0x02, 0x77, 0xa0, 0x4c, 0xbc, 0xd9, 0xed,
-	0x83, 0x30, 0x1f, 0xc3, 0xd3, 0x5e, 0x44, 0x79, 0xdb, 0xe9, 0xe1, 0x11, 0x51, 0x99, 0xc5, 0x38,
-	0x84, 0xdd, 0xfc, 0xc6, 0x22, 0xb8, 0x43, 0x5f, 0x43, 0x45, 0xbc, 0xe5, 0x92, 0x49, 0xf2, 0x46,
-	0xa7, 0x65, 0x48, 0x91, 0x24, 0x8e, 0xe5, 0x3e, 0x49, 0xf2, 0xd0, 0xe7, 0xf0, 0x58, 0x3d, 0x8d,
-	0x28, 0x63, 0xde, 0x3b, 0x75, 0x23, 0xeb, 0xc4, 0x0e, 0xe2, 0x3d, 0x40, 0xa7, 0x34, 0xce, 0x37,
-	0xf1, 0x57, 0x01, 0x9a, 0x56, 0xf8, 0x7f, 0x6c, 0x41, 0x8c, 0x4d, 0xec, 0x87, 0xbc, 0xac, 0x17,
-	0x2e, 0x92, 0xe9, 0xd0, 0x01, 0x7e, 0x2a, 0xce, 0xb9, 0xcf, 0xd2, 0xce, 0xb2, 0x7b, 0x57, 0xf8,
-	0xd0, 0xbd, 0xc3, 0xdf, 0x41, 0x5d, 0x01, 0x45, 0xef, 0xe6, 0xdd, 0x2f, 0x7c, 0xf0, 0xee, 0xb7,
-	0xa1, 0x29, 0xa0, 0x6a, 0x96, 0x98, 0x62, 0xe0, 0xc7, 0x28, 0x2e, 0xbe, 0x82, 0xf3, 0xc3, 0x95,
-	0x0b, 0xfc, 0x1e, 0xa0, 0x3b, 0x9d, 0xea, 0xe6, 0x2a, 0x53, 0x89, 0x91, 0xd3, 0x6b, 0xd5, 0x50,
-	0x5c, 0x24, 0xd9, 0x17, 0x99, 0xaa, 0x9a, 0x94, 0x64, 0x5d, 0x37, 0xc9, 0x3e, 0xfa, 0x08, 0x2a,
-	0xf2, 0x1e, 0x89, 0xd1, 0x11, 0x85, 0x93, 0x15, 0x06, 0xa8, 0xc9, 0xca, 0xbc, 0x37, 0x4c, 0x00,
-	0xf8, 0x69, 0xa5, 0x5d, 0x3c, 0xe8, 0x5d, 0x0d, 0xfe, 0xa2, 0xc5, 0x7f, 0x06, 0x4d, 0x3d, 0x4d,
-	0x84, 0xb2, 0x65, 0x10, 0xa3, 0x6f, 0xc0, 0x09, 0xb3, 0x58, 0x4a, 0x6e, 0x9c, 0x81, 0x01, 0x30,
-	0x13, 0xf1, 0x4f, 0xb0, 0x93, 0x4d, 0x56, 0x42, 0x75, 0x0c, 0xce, 0x75, 0x12, 0xf2, 0x33, 0x3f,
-	0xda, 0xd5, 0x54, 0x3a, 0xdf, 0xcc, 0xc3, 0xc7, 0xf0, 0xa4, 0xe7, 0x45, 0x53, 0x7f, 0xe6, 0x05,
-	0x7e, 0x9c, 0x72, 0xbd, 0x04, 0x67, 0xa2, 0x83, 0xf2, 0x6a, 0x94, 0x88, 0x19, 0xc2, 0x6f, 0xa0,
-	0x21, 0x26, 0xd4, 0x9f, 0xbd, 0x63, 0x09, 0x66, 0x1f, 0x6a, 0x51, 0x12, 0x49, 0xde, 0xa3, 0xa1,
-	0x8b, 0x8b, 0x5c, 0x92, 0xed, 0x73, 0x29, 0xc4, 0x8c, 0x98, 0x6a, 0x88, 0x0b, 0x71, 0x04, 0xd5,
-	0x48, 0x72, 0xa5, 0x04, 0xee, 0x5a, 0x21, 0x64, 0x0a, 0x49, 0x53, 0xb9, 0x14, 0x4f, 0x38, 0x97,
-	0xa1, 0x86, 0xa0, 0x7a, 0x9d, 0xa7, 0xfa, 0x64, 0x9d, 0x10, 0x39, 0xa6, 0x73, 0xd8, 0xe5, 0x4c,
-	0x96, 0x1a, 0x82, 0xeb, 0x38, 0xcf, 0xf5, 0x4c, 0x73, 0xad, 0x48, 0xa7, 0xd9, 0x06, 0x72, 0xe0,
-	0xb5, 0x48, 0x82, 0xaa, 0x93, 0xa7, 0x6a, 0xd9, 0x12, 0x69, 0x39, 0x33, 0x9e, 0xfd, 0x23, 0x00,
-	0x3d, 0x89, 0xa8, 0x06, 0xe5, 0x51, 0x7f, 0xf4, 0x63, 0xb3, 0x20, 0x9e, 0x06, 0xa4, 0xff, 0x6b,
-	0xb3, 0x28, 0x9e, 0x48, 0xf7, 0xe2, 0xe7, 0x66, 0x49, 0x3c, 0xf5, 0xba, 0xe4, 0xa4, 0x59, 0xde,
-	0x3f, 0x83, 0x86, 0x6d, 0x21, 0xc8, 0x81, 0xea, 0x65, 0xff, 0xe2, 0x64, 0x78, 0x71, 0xca, 0xc1,
-	0x3b, 0xe0, 0x0c, 0x2f, 0x7e, 0xbf, 0x24, 0xbf, 0x9c, 0x92, 0xfe, 0x78, 0xcc, 0x39, 0x1a, 0xbc,
-	0xca, 0x55, 0xaf, 0xc7, 0x17, 0x83, 0xab, 0x73, 0xce, 0x04, 0x50, 0x19, 0x74, 0x87, 0xe7, 0x7d,
-	0xce, 0xd5, 0xf9, 0xa7, 0x2a, 0x3e, 0x5c, 0xe2, 0x2b, 0x8f, 0x08, 0x34, 0x6c, 0x2f, 0x45, 0x9f,
-	0x1a, 0x62, 0xac, 0xb3, 0x5f, 0xf7, 0xc5, 0xe6, 0x04, 0x31, 0x69, 0x8f, 0xd0, 0x10, 0x1c, 0xc3,
-	0x19, 0xd1, 0x73, 0x9d, 0xbf, 0xea, 0xa3, 0xae, 0xbb, 0x61, 0x57, 0x51, 0x1d, 0x41, 0x59, 0xd8,
-	0x0c, 0x32, 0x3e, 0x6b, 0x86, 0xd5, 0xb9, 0xbb, 0xf9, 0xb0, 0x42, 0xbd, 0x82, 0xaa, 0x58, 0x76,
-	0x83, 0x00, 0xed, 0xe8, 0x0c, 0xf9, 0xf7, 0xb2, 0x09, 0xf2, 0x46, 0x79, 0x68, 0xe2, 0x67, 0xab,
-	0x30, 0xd7, 0x86, 0x99, 0xbe, 0x27, 0xdb, 0xdc, 0x56, 0x52, 0x24, 0xff, 0x16, 0x2b, 0xae, 0xe6,
-	0xae, 0x44, 0x38, 0xea, 0x35, 0x6c, 0x9f, 0xd0, 0x80, 0xfe, 0x07, 0x2a, 0xdf, 0x86, 0x7c, 0xb7,
-	0x3a, 0xd7, 0xe9, 0x41, 0x75, 0xb2, 0xee, 0x92, 0x9f, 0x95, 0x15, 0xaf, 0x73, 0x57, 0x22, 0x66,
-	0x77, 0x1b, 0x51, 0x1b, 0xbb, 0x7b, 0x50, 0x9d, 0x57, 0x50, 0xe2, 0x2e, 0x8d, 0x0c, 0x8f, 0xd4,
-	0x9f, 0x0b, 0x17, 0xe5, 0xa2, 0x4a, 0xee, 0x3e, 0x3c, 0xb6, 0xdc, 0xc6, 0x04, 0x6b, 0x97, 0x77,
-	0xed, 0x8b, 0x97, 0x33, 0x27, 0x4e, 0xd3, 0x83, 0x6d, 0xd3, 0x68, 0x36, 0xb0, 0x3c, 0xb3, 0xa2,
-	0xb6, 0x2d, 0x71, 0x92, 0x53, 0x68, 0xd8, 0x1e, 0xb3, 0x81, 0xe6, 0x85, 0x15, 0xcd, 0x7b, 0x12,
-	0x27, 0xea, 0xca, 0xa9, 0x49, 0x4d, 0x63, 0x03, 0x8b, 0x3d, 0x2d, 0x96, 0x17, 0xe1, 0x47, 0xff,
-	0x06, 0x00, 0x00, 0xff, 0xff, 0xc7, 0x64, 0x21, 0x77, 0xd2, 0x0b, 0x00, 0x00,
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "skizze.proto",
 }
@@ -0,0 +1,336 @@
+package skizze
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	defaultBatchMaxValues     = 1000
+	defaultBatchFlushInterval = time.Second
+	defaultBatchWorkers       = 4
+)
+
+// ErrBatchWriterClosed is returned when adding values to a closed BatchWriter.
+var ErrBatchWriterClosed = errors.New("skizze: batch writer closed")
+
+// BatchOptions configures a BatchWriter.
+type BatchOptions struct {
+	// MaxValues is the number of values buffered for a domain or sketch before they are
+	// flushed. Defaults to 1000.
+	MaxValues int
+
+	// FlushInterval is the longest values are buffered before being flushed. Defaults
+	// to 1s.
+	FlushInterval time.Duration
+
+	// Workers is the number of concurrent flushes. Defaults to 4.
+	Workers int
+
+	// OnError, if set, is called from a flush worker whenever a flush fails. It may add
+	// the values to the writer again; once the writer is closed they are rejected with
+	// ErrBatchWriterClosed.
+	OnError func(*BatchError)
+}
+
+// BatchError describes a failed flush of a BatchWriter.
+type BatchError struct {
+	// Domain is the name of the domain values were added to, or empty for a sketch.
+	Domain string
+	// Sketch and Type identify the sketch values were added to, if Domain is empty.
+	Sketch string
+	Type   SketchType
+	// Values are the values which were not added.
+	Values []string
+	// Err is the error returned by Skizze.
+	Err error
+}
+
+func (e *BatchError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+type batchTarget struct {
+	domain string
+	sketch string
+	typ    SketchType
+}
+
+type batch struct {
+	ctx    context.Context
+	target batchTarget
+	values []string
+	done   chan error
+}
+
+// BatchWriter buffers values added to domains and sketches and sends them to Skizze in
+// batches, reducing the number of requests made. It is safe for concurrent use.
+type BatchWriter struct {
+	client *Client
+	opts   BatchOptions
+
+	// ctx is used by size and interval flushes. It is cancelled when the writer is closed.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	closed  bool
+	buffers map[batchTarget][]string
+	// sending counts the Add and Flush calls handing batches to the workers, which must
+	// be done before jobs is closed.
+	sending sync.WaitGroup
+
+	jobs    chan *batch
+	workers sync.WaitGroup
+	stop    chan struct{}
+	ticker  sync.WaitGroup
+}
+
+// NewBatchWriter returns a BatchWriter which adds values using c. It must be closed to
+// ensure all buffered values are sent.
+func (c *Client) NewBatchWriter(opts BatchOptions) *BatchWriter {
+	if opts.MaxValues <= 0 {
+		opts.MaxValues = defaultBatchMaxValues
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultBatchFlushInterval
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = defaultBatchWorkers
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &BatchWriter{
+		client:  c,
+		ctx:     ctx,
+		cancel:  cancel,
+		opts:    opts,
+		buffers: make(map[batchTarget][]string),
+		jobs:    make(chan *batch, opts.Workers),
+		stop:    make(chan struct{}),
+	}
+
+	w.workers.Add(opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
+		go w.work()
+	}
+
+	w.ticker.Add(1)
+	go w.tick()
+
+	return w
+}
+
+// AddToDomain buffers values to be added to the domain's data set.
+func (w *BatchWriter) AddToDomain(name string, values ...string) error {
+	return w.add(batchTarget{domain: name}, values)
+}
+
+// AddToSketch buffers values to be added to the sketch's data set.
+func (w *BatchWriter) AddToSketch(name string, t SketchType, values ...string) error {
+	if _, err := getRawSketchForSketchType(t); err != nil {
+		return newClientError("AddToSketch", err)
+	}
+	return w.add(batchTarget{sketch: name, typ: t}, values)
+}
+
+func (w *BatchWriter) add(t batchTarget, values []string) error {
+	var full []*batch
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrBatchWriterClosed
+	}
+	buf := w.buffers[t]
+	for _, v := range values {
+		buf = append(buf, v)
+		if len(buf) >= w.opts.MaxValues {
+			full = append(full, &batch{ctx: w.ctx, target: t, values: buf})
+			buf = nil
+		}
+	}
+	w.buffers[t] = buf
+	w.sending.Add(1)
+	w.mu.Unlock()
+
+	defer w.sending.Done()
+	for _, b := range full {
+		w.jobs <- b
+	}
+	return nil
+}
+
+// Flush sends all buffered values and waits for them to be added. It returns the first
+// error encountered, which is also reported to BatchOptions.OnError. Values already
+// handed to a worker by a size or interval flush are not waited for.
+func (w *BatchWriter) Flush() error {
+	return w.FlushContext(context.Background())
+}
+
+// FlushContext is like Flush but uses the supplied context for the requests it makes.
+// Values not yet handed to a worker when ctx is done are reported as failed.
+func (w *BatchWriter) FlushContext(ctx context.Context) error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrBatchWriterClosed
+	}
+	batches := w.takeBuffers(ctx, true)
+	w.sending.Add(1)
+	w.mu.Unlock()
+
+	w.send(ctx, batches)
+	w.sending.Done()
+	return w.wait(batches)
+}
+
+// Close sends all buffered values, waits for in-flight flushes to complete and stops the
+// writer. It returns the first error from the final flush.
+func (w *BatchWriter) Close() error {
+	return w.CloseContext(context.Background())
+}
+
+// CloseContext is like Close but uses the supplied context for the final flush. Once ctx
+// is done, requests still in flight are cancelled and their values reported as failed.
+func (w *BatchWriter) CloseContext(ctx context.Context) error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrBatchWriterClosed
+	}
+	w.closed = true
+	batches := w.takeBuffers(ctx, true)
+	w.mu.Unlock()
+
+	close(w.stop)
+	w.ticker.Wait()
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.cancel()
+		case <-stopped:
+		}
+	}()
+
+	w.send(ctx, batches)
+	err := w.wait(batches)
+
+	w.sending.Wait()
+	close(w.jobs)
+	w.workers.Wait()
+	w.cancel()
+	return err
+}
+
+// takeBuffers empties the buffers into batches using ctx. If wait is true, the batches
+// report their result on done. The caller must hold w.mu.
+func (w *BatchWriter) takeBuffers(ctx context.Context, wait bool) []*batch {
+	var batches []*batch
+	for t, buf := range w.buffers {
+		if len(buf) > 0 {
+			b := &batch{ctx: ctx, target: t, values: buf}
+			if wait {
+				b.done = make(chan error, 1)
+			}
+			batches = append(batches, b)
+		}
+		delete(w.buffers, t)
+	}
+	return batches
+}
+
+// send hands batches to the workers. Batches not handed over before ctx is done fail
+// with its error.
+func (w *BatchWriter) send(ctx context.Context, batches []*batch) {
+	for _, b := range batches {
+		select {
+		case w.jobs <- b:
+		case <-ctx.Done():
+			w.finish(b, ctx.Err())
+		}
+	}
+}
+
+// wait returns the first error of batches once they are all done.
+func (w *BatchWriter) wait(batches []*batch) error {
+	var first error
+	for _, b := range batches {
+		if err := <-b.done; err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// interval flushes the buffers without waiting for the values to be added.
+func (w *BatchWriter) interval() {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return
+	}
+	batches := w.takeBuffers(w.ctx, false)
+	w.mu.Unlock()
+
+	w.send(w.ctx, batches)
+}
+
+func (w *BatchWriter) tick() {
+	defer w.ticker.Done()
+
+	t := time.NewTicker(w.opts.FlushInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-t.C:
+			w.interval()
+		}
+	}
+}
+
+func (w *BatchWriter) work() {
+	defer w.workers.Done()
+
+	for b := range w.jobs {
+		var err error
+		if b.target.domain != "" {
+			err = w.client.AddToDomainContext(b.ctx, b.target.domain, b.values...)
+		} else {
+			err = w.client.AddToSketchContext(b.ctx, b.target.sketch, b.target.typ, b.values...)
+		}
+		w.finish(b, err)
+	}
+}
+
+// finish reports the result of b, calling OnError if it failed.
+func (w *BatchWriter) finish(b *batch, err error) {
+	if err != nil {
+		berr := &BatchError{
+			Domain: b.target.domain,
+			Sketch: b.target.sketch,
+			Type:   b.target.typ,
+			Values: b.values,
+			Err:    err,
+		}
+		if w.opts.OnError != nil {
+			w.opts.OnError(berr)
+		}
+		err = berr
+	}
+	if b.done != nil {
+		b.done <- err
+	}
+}
@@ -0,0 +1,186 @@
+package skizze_test
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/skizzehq/goskizze/protobuf"
+	. "github.com/skizzehq/goskizze/skizze"
+)
+
+// addedValues returns the values sent to the fake in Add requests, keyed by domain or
+// sketch name.
+func addedValues(fs *fakeSkizze) map[string][]string {
+	ret := make(map[string][]string)
+	for _, r := range fs.allRequests() {
+		req, ok := r.(*pb.AddRequest)
+		if !ok {
+			continue
+		}
+		name := req.GetDomain().GetName()
+		if req.Sketch != nil {
+			name = req.GetSketch().GetName()
+		}
+		ret[name] = append(ret[name], req.GetValues()...)
+	}
+	for _, v := range ret {
+		sort.Strings(v)
+	}
+	return ret
+}
+
+func TestBatchWriterSizeFlush(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.AddReply{}
+
+	w := c.NewBatchWriter(BatchOptions{MaxValues: 2, FlushInterval: time.Hour})
+	assert.Nil(w.AddToDomain("mydomain", "a", "b", "c"))
+	assert.Nil(w.AddToSketch("mysketch", Cardinality, "x"))
+
+	// Only the full batch has been sent so far.
+	assert.Eventually(func() bool { return len(fs.allRequests()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal([]string{"a", "b"}, addedValues(fs)["mydomain"])
+
+	assert.Nil(w.Close())
+	added := addedValues(fs)
+	assert.Equal([]string{"a", "b", "c"}, added["mydomain"])
+	assert.Equal([]string{"x"}, added["mysketch"])
+
+	assert.Equal(ErrBatchWriterClosed, w.AddToDomain("mydomain", "d"))
+}
+
+func TestBatchWriterIntervalFlush(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.AddReply{}
+
+	w := c.NewBatchWriter(BatchOptions{FlushInterval: 10 * time.Millisecond})
+	defer w.Close()
+
+	assert.Nil(w.AddToDomain("mydomain", "a", "b"))
+	assert.Eventually(func() bool { return len(addedValues(fs)["mydomain"]) == 2 }, time.Second, time.Millisecond)
+}
+
+func TestBatchWriterFlush(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.AddReply{}
+
+	w := c.NewBatchWriter(BatchOptions{FlushInterval: time.Hour, Workers: 2})
+	defer w.Close()
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(w.AddToSketch("mysketch", Frequency, "a"))
+		assert.Nil(w.AddToDomain("mydomain", "b"))
+	}
+	assert.Nil(w.Flush())
+
+	added := addedValues(fs)
+	assert.Equal([]string{"a", "a", "a"}, added["mysketch"])
+	assert.Equal([]string{"b", "b", "b"}, added["mydomain"])
+}
+
+func TestBatchWriterErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.AddReply{}
+	fs.nextError = status.Error(codes.NotFound, "no such domain")
+
+	errs := make(chan *BatchError, 1)
+	w := c.NewBatchWriter(BatchOptions{
+		FlushInterval: time.Hour,
+		OnError:       func(e *BatchError) { errs <- e },
+	})
+
+	assert.Nil(w.AddToDomain("mydomain", "a", "b"))
+	err := w.Close()
+	assert.True(errors.Is(err, ErrNotFound))
+
+	e := <-errs
+	assert.Equal("mydomain", e.Domain)
+	assert.Equal([]string{"a", "b"}, e.Values)
+	assert.True(errors.Is(e, ErrNotFound))
+
+	assert.True(errors.Is(w.AddToSketch("mysketch", SketchType(42), "a"), ErrInvalidSketchType))
+}
+
+func TestBatchWriterRequeue(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.AddReply{}
+	fs.nextError = status.Error(codes.NotFound, "no such domain")
+
+	// Failed values are added again until the writer is closed.
+	var w *BatchWriter
+	requeued := make(chan error, 2)
+	w = c.NewBatchWriter(BatchOptions{
+		FlushInterval: time.Hour,
+		OnError:       func(e *BatchError) { requeued <- w.AddToDomain(e.Domain, e.Values...) },
+	})
+
+	assert.Nil(w.AddToDomain("mydomain", "a"))
+	assert.True(errors.Is(w.Flush(), ErrNotFound))
+	assert.Nil(<-requeued)
+
+	closed := make(chan error)
+	go func() { closed <- w.Close() }()
+	select {
+	case err := <-closed:
+		assert.True(errors.Is(err, ErrNotFound))
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close blocked by OnError")
+	}
+	assert.Equal(ErrBatchWriterClosed, <-requeued)
+}
+
+func TestBatchWriterCloseContext(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getHangingClient(t)
+	defer closeAll(c, fs)
+
+	var failed []string
+	var mu sync.Mutex
+	w := c.NewBatchWriter(BatchOptions{
+		MaxValues:     2,
+		FlushInterval: time.Hour,
+		OnError: func(e *BatchError) {
+			mu.Lock()
+			failed = append(failed, e.Values...)
+			mu.Unlock()
+		},
+	})
+	assert.Nil(w.AddToDomain("mydomain", "a", "b", "c"))
+
+	// Both the final flush and the size flush in flight are cancelled.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := w.CloseContext(ctx)
+	assert.True(errors.Is(err, context.DeadlineExceeded))
+	sort.Strings(failed)
+	assert.Equal([]string{"a", "b", "c"}, failed)
+}
@@ -1,10 +1,11 @@
 package skizze
 
 import (
-	"fmt"
+	"time"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	pb "github.com/skizzehq/goskizze/protobuf"
 )
@@ -16,6 +17,9 @@ var (
 	typeCard = pb.SketchType_CARD
 )
 
+// snapshotPollInterval is how often WaitForSnapshot checks the snapshot status.
+var snapshotPollInterval = 500 * time.Millisecond
+
 // Client represents a a thread-safe connection to Skizze
 type Client struct {
 	opts Options
@@ -29,11 +33,30 @@ func Dial(address string, opts Options) (*Client, error) {
 	var gOpts []grpc.DialOption
 	if opts.Insecure == true {
 		gOpts = append(gOpts, grpc.WithInsecure())
+	} else {
+		cfg, err := opts.tlsConfig()
+		if err != nil {
+			return nil, newDialError(address, err)
+		}
+		gOpts = append(gOpts, grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+	}
+	if opts.PerRPCCredentials != nil {
+		gOpts = append(gOpts, grpc.WithPerRPCCredentials(opts.PerRPCCredentials))
+	}
+
+	var interceptors []grpc.UnaryClientInterceptor
+	if opts.RetryPolicy != nil {
+		interceptors = append(interceptors, opts.RetryPolicy.interceptor())
+	}
+	interceptors = append(interceptors, opts.UnaryInterceptors...)
+	if len(interceptors) > 0 {
+		gOpts = append(gOpts, grpc.WithChainUnaryInterceptor(interceptors...))
 	}
+	gOpts = append(gOpts, opts.DialOptions...)
 
 	conn, err := grpc.Dial(address, gOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("Unable to dial Skizze at %v: %v", address, err)
+		return nil, newDialError(address, err)
 	}
 
 	return &Client{
@@ -43,6 +66,12 @@ func Dial(address string, opts Options) (*Client, error) {
 	}, nil
 }
 
+// NewClientFromConn returns a client which uses an existing connection to Skizze. The
+// caller remains responsible for closing conn; Close on the returned client is a no-op.
+func NewClientFromConn(conn *grpc.ClientConn) *Client {
+	return &Client{client: pb.NewSkizzeClient(conn)}
+}
+
 // Close shuts down the client connection to Skizze.
 func (c *Client) Close() {
 	if c.conn != nil {
@@ -52,9 +81,14 @@ func (c *Client) Close() {
 
 // ListAll gets all the available Sketches.
 func (c *Client) ListAll() (ret []*Sketch, err error) {
-	reply, err := c.client.ListAll(context.Background(), &pb.Empty{})
+	return c.ListAllContext(context.Background())
+}
+
+// ListAllContext is like ListAll but uses the supplied context.
+func (c *Client) ListAllContext(ctx context.Context) (ret []*Sketch, err error) {
+	reply, err := c.client.ListAll(ctx, &pb.Empty{})
 	if err != nil {
-		return nil, err
+		return nil, newError("ListAll", err)
 	}
 	for _, sketch := range reply.GetSketches() {
 		ret = append(ret, newSketchFromRaw(sketch))
@@ -64,10 +98,18 @@ func (c *Client) ListAll() (ret []*Sketch, err error) {
 
 // ListSketches gets all the sketches of the specified type.
 func (c *Client) ListSketches(t SketchType) (ret []*Sketch, err error) {
-	rt := getRawSketchForSketchType(t)
-	reply, err := c.client.List(context.Background(), &pb.ListRequest{Type: &rt})
+	return c.ListSketchesContext(context.Background(), t)
+}
+
+// ListSketchesContext is like ListSketches but uses the supplied context.
+func (c *Client) ListSketchesContext(ctx context.Context, t SketchType) (ret []*Sketch, err error) {
+	rt, err := getRawSketchForSketchType(t)
 	if err != nil {
-		return nil, err
+		return nil, newClientError("ListSketches", err)
+	}
+	reply, err := c.client.List(ctx, &pb.ListRequest{Type: &rt})
+	if err != nil {
+		return nil, newError("ListSketches", err)
 	}
 	for _, sketch := range reply.GetSketches() {
 		ret = append(ret, newSketchFromRaw(sketch))
@@ -77,15 +119,25 @@ func (c *Client) ListSketches(t SketchType) (ret []*Sketch, err error) {
 
 // ListDomains gets all the available domains
 func (c *Client) ListDomains() (ret []string, err error) {
-	reply, err := c.client.ListDomains(context.Background(), &pb.Empty{})
+	return c.ListDomainsContext(context.Background())
+}
+
+// ListDomainsContext is like ListDomains but uses the supplied context.
+func (c *Client) ListDomainsContext(ctx context.Context) (ret []string, err error) {
+	reply, err := c.client.ListDomains(ctx, &pb.Empty{})
 	if err != nil {
-		return nil, err
+		return nil, newError("ListDomains", err)
 	}
 	return reply.GetNames(), nil
 }
 
 // CreateDomain creates a new domain with default properties per Sketch.
 func (c *Client) CreateDomain(name string) (*Domain, error) {
+	return c.CreateDomainContext(context.Background(), name)
+}
+
+// CreateDomainContext is like CreateDomain but uses the supplied context.
+func (c *Client) CreateDomainContext(ctx context.Context, name string) (*Domain, error) {
 	rd := &pb.Domain{Name: &name}
 
 	rd.Sketches = append(rd.Sketches, &pb.Sketch{
@@ -116,15 +168,20 @@ func (c *Client) CreateDomain(name string) (*Domain, error) {
 		Type: &typeCard,
 	})
 
-	reply, err := c.client.CreateDomain(context.Background(), rd)
+	reply, err := c.client.CreateDomain(ctx, rd)
 	if err != nil {
-		return nil, err
+		return nil, newError("CreateDomain", err)
 	}
 	return newDomainFromRaw(reply), nil
 }
 
 // CreateDomainWithProperties creates a domain with customized properties.
 func (c *Client) CreateDomainWithProperties(name string, props *DomainProperties) (*Domain, error) {
+	return c.CreateDomainWithPropertiesContext(context.Background(), name, props)
+}
+
+// CreateDomainWithPropertiesContext is like CreateDomainWithProperties but uses the supplied context.
+func (c *Client) CreateDomainWithPropertiesContext(ctx context.Context, name string, props *DomainProperties) (*Domain, error) {
 	rd := &pb.Domain{Name: &name}
 
 	rd.Sketches = append(rd.Sketches, &pb.Sketch{
@@ -147,89 +204,148 @@ func (c *Client) CreateDomainWithProperties(name string, props *DomainProperties
 		Type: &typeCard,
 	})
 
-	reply, err := c.client.CreateDomain(context.Background(), rd)
+	reply, err := c.client.CreateDomain(ctx, rd)
 	if err != nil {
-		return nil, err
+		return nil, newError("CreateDomainWithProperties", err)
 	}
 	return newDomainFromRaw(reply), nil
 }
 
 // DeleteDomain deletes a domain
 func (c *Client) DeleteDomain(name string) error {
+	return c.DeleteDomainContext(context.Background(), name)
+}
+
+// DeleteDomainContext is like DeleteDomain but uses the supplied context.
+func (c *Client) DeleteDomainContext(ctx context.Context, name string) error {
 	rd := &pb.Domain{Name: &name}
-	_, err := c.client.DeleteDomain(context.Background(), rd)
+	_, err := c.client.DeleteDomain(ctx, rd)
 	if err != nil {
-		return err
+		return newError("DeleteDomain", err)
 	}
 	return nil
 }
 
 // GetDomain gets the details of a domain.
 func (c *Client) GetDomain(name string) (*Domain, error) {
+	return c.GetDomainContext(context.Background(), name)
+}
+
+// GetDomainContext is like GetDomain but uses the supplied context.
+func (c *Client) GetDomainContext(ctx context.Context, name string) (*Domain, error) {
 	rd := &pb.Domain{Name: &name}
-	reply, err := c.client.GetDomain(context.Background(), rd)
+	reply, err := c.client.GetDomain(ctx, rd)
 	if err != nil {
-		return nil, err
+		return nil, newError("GetDomain", err)
 	}
 	return newDomainFromRaw(reply), nil
 }
 
 // CreateSketch creates a new sketch.
 func (c *Client) CreateSketch(name string, t SketchType, p *Properties) (*Sketch, error) {
-	rt := getRawSketchForSketchType(t)
+	return c.CreateSketchContext(context.Background(), name, t, p)
+}
+
+// CreateSketchContext is like CreateSketch but uses the supplied context.
+func (c *Client) CreateSketchContext(ctx context.Context, name string, t SketchType, p *Properties) (*Sketch, error) {
+	rt, err := getRawSketchForSketchType(t)
+	if err != nil {
+		return nil, newClientError("CreateSketch", err)
+	}
 	rd := &pb.Sketch{Name: &name, Type: &rt, Properties: newRawPropertiesFromProperties(p)}
-	reply, err := c.client.CreateSketch(context.Background(), rd)
+	reply, err := c.client.CreateSketch(ctx, rd)
 	if err != nil {
-		return nil, err
+		return nil, newError("CreateSketch", err)
 	}
 	return newSketchFromRaw(reply), nil
 }
 
 // DeleteSketch deletes a sketch
 func (c *Client) DeleteSketch(name string, t SketchType) error {
-	rt := getRawSketchForSketchType(t)
+	return c.DeleteSketchContext(context.Background(), name, t)
+}
+
+// DeleteSketchContext is like DeleteSketch but uses the supplied context.
+func (c *Client) DeleteSketchContext(ctx context.Context, name string, t SketchType) error {
+	rt, err := getRawSketchForSketchType(t)
+	if err != nil {
+		return newClientError("DeleteSketch", err)
+	}
 	rd := &pb.Sketch{Name: &name, Type: &rt}
-	_, err := c.client.DeleteSketch(context.Background(), rd)
+	_, err = c.client.DeleteSketch(ctx, rd)
 	if err != nil {
-		return err
+		return newError("DeleteSketch", err)
 	}
 	return nil
 }
 
 // GetSketch gets the details of a sketch.
 func (c *Client) GetSketch(name string, t SketchType) (*Sketch, error) {
-	rt := getRawSketchForSketchType(t)
+	return c.GetSketchContext(context.Background(), name, t)
+}
+
+// GetSketchContext is like GetSketch but uses the supplied context.
+func (c *Client) GetSketchContext(ctx context.Context, name string, t SketchType) (*Sketch, error) {
+	rt, err := getRawSketchForSketchType(t)
+	if err != nil {
+		return nil, newClientError("GetSketch", err)
+	}
 	rd := &pb.Sketch{Name: &name, Type: &rt}
-	reply, err := c.client.GetSketch(context.Background(), rd)
+	reply, err := c.client.GetSketch(ctx, rd)
 	if err != nil {
-		return nil, err
+		return nil, newError("GetSketch", err)
 	}
 	return newSketchFromRaw(reply), nil
 }
 
 // AddToSketch will add the supplied values to the sketch's data set.
 func (c *Client) AddToSketch(name string, t SketchType, values ...string) error {
-	rt := getRawSketchForSketchType(t)
+	return c.AddToSketchContext(context.Background(), name, t, values...)
+}
+
+// AddToSketchContext is like AddToSketch but uses the supplied context.
+func (c *Client) AddToSketchContext(ctx context.Context, name string, t SketchType, values ...string) error {
+	rt, err := getRawSketchForSketchType(t)
+	if err != nil {
+		return newClientError("AddToSketch", err)
+	}
 	rs := pb.Sketch{Name: &name, Type: &rt}
-	_, err := c.client.Add(context.Background(), &pb.AddRequest{Sketch: &rs, Values: values})
-	return err
+	_, err = c.client.Add(ctx, &pb.AddRequest{Sketch: &rs, Values: values})
+	return newError("AddToSketch", err)
 }
 
 // AddToDomain will add the supplied values to the domain's data set.
 func (c *Client) AddToDomain(name string, values ...string) error {
+	return c.AddToDomainContext(context.Background(), name, values...)
+}
+
+// AddToDomainContext is like AddToDomain but uses the supplied context.
+func (c *Client) AddToDomainContext(ctx context.Context, name string, values ...string) error {
 	rd := pb.Domain{Name: &name}
-	_, err := c.client.Add(context.Background(), &pb.AddRequest{Domain: &rd, Values: values})
-	return err
+	_, err := c.client.Add(ctx, &pb.AddRequest{Domain: &rd, Values: values})
+	return newError("AddToDomain", err)
 }
 
 // GetMembership queries the sketch for membership (true/false) for the provided values.
 func (c *Client) GetMembership(name string, values ...string) (ret []*MembershipResult, err error) {
+	return c.GetMembershipContext(context.Background(), name, values...)
+}
+
+// GetMembershipContext is like GetMembership but uses the supplied context.
+func (c *Client) GetMembershipContext(ctx context.Context, name string, values ...string) (ret []*MembershipResult, err error) {
 	rs := pb.Sketch{Name: &name, Type: &typeMemb}
-	reply, err := c.client.GetMembership(context.Background(), &pb.GetRequest{Sketches: []*pb.Sketch{&rs}, Values: values})
+	reply, err := c.client.GetMembership(ctx, &pb.GetRequest{Sketches: []*pb.Sketch{&rs}, Values: values})
 	if err != nil {
-		return nil, err
+		return nil, newError("GetMembership", err)
+	}
+	if err := checkResults(len(reply.GetResults()), 1); err != nil {
+		return nil, newClientError("GetMembership", err)
+	}
+	result := reply.GetResults()[0]
+	if err := checkValues(len(result.GetMemberships()), len(values)); err != nil {
+		return nil, newClientError("GetMembership", err)
 	}
-	for _, m := range reply.GetResults()[0].GetMemberships() {
+	for _, m := range result.GetMemberships() {
 		ret = append(ret, &MembershipResult{Value: m.GetValue(), IsMember: m.GetIsMember()})
 	}
 	return ret, nil
@@ -237,17 +353,28 @@ func (c *Client) GetMembership(name string, values ...string) (ret []*Membership
 
 // GetMultiMembership queries multiple sketches for membership of the provided values.
 func (c *Client) GetMultiMembership(names []string, values ...string) (ret [][]*MembershipResult, err error) {
+	return c.GetMultiMembershipContext(context.Background(), names, values...)
+}
+
+// GetMultiMembershipContext is like GetMultiMembership but uses the supplied context.
+func (c *Client) GetMultiMembershipContext(ctx context.Context, names []string, values ...string) (ret [][]*MembershipResult, err error) {
 	req := &pb.GetRequest{Values: values}
 	for i := range names {
 		req.Sketches = append(req.Sketches, &pb.Sketch{Name: &names[i], Type: &typeMemb})
 	}
 
-	reply, err := c.client.GetMembership(context.Background(), req)
+	reply, err := c.client.GetMembership(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, newError("GetMultiMembership", err)
+	}
+	if err := checkResults(len(reply.GetResults()), len(names)); err != nil {
+		return nil, newClientError("GetMultiMembership", err)
 	}
 
 	for _, result := range reply.GetResults() {
+		if err := checkValues(len(result.GetMemberships()), len(values)); err != nil {
+			return nil, newClientError("GetMultiMembership", err)
+		}
 		r := []*MembershipResult{}
 		for _, m := range result.GetMemberships() {
 			r = append(r, &MembershipResult{Value: m.GetValue(), IsMember: m.GetIsMember()})
@@ -259,12 +386,24 @@ func (c *Client) GetMultiMembership(names []string, values ...string) (ret [][]*
 
 // GetFrequency queries the sketch for frequency for the provided values.
 func (c *Client) GetFrequency(name string, values ...string) (ret []*FrequencyResult, err error) {
+	return c.GetFrequencyContext(context.Background(), name, values...)
+}
+
+// GetFrequencyContext is like GetFrequency but uses the supplied context.
+func (c *Client) GetFrequencyContext(ctx context.Context, name string, values ...string) (ret []*FrequencyResult, err error) {
 	rs := pb.Sketch{Name: &name, Type: &typeFreq}
-	reply, err := c.client.GetFrequency(context.Background(), &pb.GetRequest{Sketches: []*pb.Sketch{&rs}, Values: values})
+	reply, err := c.client.GetFrequency(ctx, &pb.GetRequest{Sketches: []*pb.Sketch{&rs}, Values: values})
 	if err != nil {
-		return nil, err
+		return nil, newError("GetFrequency", err)
 	}
-	for _, m := range reply.GetResults()[0].GetFrequencies() {
+	if err := checkResults(len(reply.GetResults()), 1); err != nil {
+		return nil, newClientError("GetFrequency", err)
+	}
+	result := reply.GetResults()[0]
+	if err := checkValues(len(result.GetFrequencies()), len(values)); err != nil {
+		return nil, newClientError("GetFrequency", err)
+	}
+	for _, m := range result.GetFrequencies() {
 		ret = append(ret, &FrequencyResult{Value: m.GetValue(), Count: m.GetCount()})
 	}
 	return ret, nil
@@ -272,15 +411,26 @@ func (c *Client) GetFrequency(name string, values ...string) (ret []*FrequencyRe
 
 // GetMultiFrequency queries multiple sketches for the frequency of the provided values.
 func (c *Client) GetMultiFrequency(names []string, values ...string) (ret [][]*FrequencyResult, err error) {
+	return c.GetMultiFrequencyContext(context.Background(), names, values...)
+}
+
+// GetMultiFrequencyContext is like GetMultiFrequency but uses the supplied context.
+func (c *Client) GetMultiFrequencyContext(ctx context.Context, names []string, values ...string) (ret [][]*FrequencyResult, err error) {
 	req := &pb.GetRequest{Values: values}
 	for i := range names {
 		req.Sketches = append(req.Sketches, &pb.Sketch{Name: &names[i], Type: &typeFreq})
 	}
-	reply, err := c.client.GetFrequency(context.Background(), req)
+	reply, err := c.client.GetFrequency(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, newError("GetMultiFrequency", err)
+	}
+	if err := checkResults(len(reply.GetResults()), len(names)); err != nil {
+		return nil, newClientError("GetMultiFrequency", err)
 	}
 	for _, result := range reply.GetResults() {
+		if err := checkValues(len(result.GetFrequencies()), len(values)); err != nil {
+			return nil, newClientError("GetMultiFrequency", err)
+		}
 		r := []*FrequencyResult{}
 		for _, m := range result.GetFrequencies() {
 			r = append(r, &FrequencyResult{Value: m.GetValue(), Count: m.GetCount()})
@@ -292,10 +442,18 @@ func (c *Client) GetMultiFrequency(names []string, values ...string) (ret [][]*F
 
 // GetRankings queries the sketch for the top rankings.
 func (c *Client) GetRankings(name string) (ret []*RankingsResult, err error) {
+	return c.GetRankingsContext(context.Background(), name)
+}
+
+// GetRankingsContext is like GetRankings but uses the supplied context.
+func (c *Client) GetRankingsContext(ctx context.Context, name string) (ret []*RankingsResult, err error) {
 	rs := pb.Sketch{Name: &name, Type: &typeRank}
-	reply, err := c.client.GetRankings(context.Background(), &pb.GetRequest{Sketches: []*pb.Sketch{&rs}})
+	reply, err := c.client.GetRankings(ctx, &pb.GetRequest{Sketches: []*pb.Sketch{&rs}})
 	if err != nil {
-		return nil, err
+		return nil, newError("GetRankings", err)
+	}
+	if err := checkResults(len(reply.GetResults()), 1); err != nil {
+		return nil, newClientError("GetRankings", err)
 	}
 	for _, m := range reply.GetResults()[0].GetRankings() {
 		ret = append(ret, &RankingsResult{Value: m.GetValue(), Count: m.GetCount()})
@@ -305,11 +463,22 @@ func (c *Client) GetRankings(name string) (ret []*RankingsResult, err error) {
 
 // GetMultiRankings queries multiple sketches for the top rankings.
 func (c *Client) GetMultiRankings(names []string) (ret [][]*RankingsResult, err error) {
+	return c.GetMultiRankingsContext(context.Background(), names)
+}
+
+// GetMultiRankingsContext is like GetMultiRankings but uses the supplied context.
+func (c *Client) GetMultiRankingsContext(ctx context.Context, names []string) (ret [][]*RankingsResult, err error) {
 	req := &pb.GetRequest{}
 	for i := range names {
 		req.Sketches = append(req.Sketches, &pb.Sketch{Name: &names[i], Type: &typeRank})
 	}
-	reply, err := c.client.GetRankings(context.Background(), req)
+	reply, err := c.client.GetRankings(ctx, req)
+	if err != nil {
+		return nil, newError("GetMultiRankings", err)
+	}
+	if err := checkResults(len(reply.GetResults()), len(names)); err != nil {
+		return nil, newClientError("GetMultiRankings", err)
+	}
 	for _, result := range reply.GetResults() {
 		r := []*RankingsResult{}
 		for _, m := range result.GetRankings() {
@@ -322,27 +491,94 @@ func (c *Client) GetMultiRankings(names []string) (ret [][]*RankingsResult, err
 
 // GetCardinality queries the sketch for the cardinality of items.
 func (c *Client) GetCardinality(name string) (int64, error) {
+	return c.GetCardinalityContext(context.Background(), name)
+}
+
+// GetCardinalityContext is like GetCardinality but uses the supplied context.
+func (c *Client) GetCardinalityContext(ctx context.Context, name string) (int64, error) {
 	rs := pb.Sketch{Name: &name, Type: &typeCard}
-	reply, err := c.client.GetCardinality(context.Background(), &pb.GetRequest{Sketches: []*pb.Sketch{&rs}})
+	reply, err := c.client.GetCardinality(ctx, &pb.GetRequest{Sketches: []*pb.Sketch{&rs}})
 	if err != nil {
-		return 0, err
+		return 0, newError("GetCardinality", err)
+	}
+	if err := checkResults(len(reply.GetResults()), 1); err != nil {
+		return 0, newClientError("GetCardinality", err)
 	}
 	return reply.GetResults()[0].GetCardinality(), nil
 }
 
 // GetMultiCardinality queries multiple sketches for the cardinality of items.
 func (c *Client) GetMultiCardinality(names []string) (ret []int64, err error) {
+	return c.GetMultiCardinalityContext(context.Background(), names)
+}
+
+// GetMultiCardinalityContext is like GetMultiCardinality but uses the supplied context.
+func (c *Client) GetMultiCardinalityContext(ctx context.Context, names []string) (ret []int64, err error) {
 	req := &pb.GetRequest{}
 	for i := range names {
 		req.Sketches = append(req.Sketches, &pb.Sketch{Name: &names[i], Type: &typeCard})
 	}
-	reply, err := c.client.GetCardinality(context.Background(), req)
+	reply, err := c.client.GetCardinality(ctx, req)
 
 	if err != nil {
-		return nil, err
+		return nil, newError("GetMultiCardinality", err)
+	}
+	if err := checkResults(len(reply.GetResults()), len(names)); err != nil {
+		return nil, newClientError("GetMultiCardinality", err)
 	}
 	for _, result := range reply.GetResults() {
 		ret = append(ret, result.GetCardinality())
 	}
 	return ret, nil
 }
+
+// CreateSnapshot asks Skizze to start a snapshot of all sketches.
+func (c *Client) CreateSnapshot() (*Snapshot, error) {
+	return c.CreateSnapshotContext(context.Background())
+}
+
+// CreateSnapshotContext is like CreateSnapshot but uses the supplied context.
+func (c *Client) CreateSnapshotContext(ctx context.Context) (*Snapshot, error) {
+	reply, err := c.client.CreateSnapshot(ctx, &pb.CreateSnapshotRequest{})
+	if err != nil {
+		return nil, newError("CreateSnapshot", err)
+	}
+	return newSnapshotFromCreateReply(reply), nil
+}
+
+// GetSnapshot gets the state of the current or previous snapshot.
+func (c *Client) GetSnapshot() (*Snapshot, error) {
+	return c.GetSnapshotContext(context.Background())
+}
+
+// GetSnapshotContext is like GetSnapshot but uses the supplied context.
+func (c *Client) GetSnapshotContext(ctx context.Context) (*Snapshot, error) {
+	reply, err := c.client.GetSnapshot(ctx, &pb.GetSnapshotRequest{})
+	if err != nil {
+		return nil, newError("GetSnapshot", err)
+	}
+	return newSnapshotFromGetReply(reply), nil
+}
+
+// WaitForSnapshot polls the snapshot state until it is either Successful or Failed, or
+// until the context is done.
+func (c *Client) WaitForSnapshot(ctx context.Context) (*Snapshot, error) {
+	ticker := time.NewTicker(snapshotPollInterval)
+	defer ticker.Stop()
+
+	for {
+		s, err := c.GetSnapshotContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if s.Status == Successful || s.Status == Failed {
+			return s, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return s, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
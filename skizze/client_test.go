@@ -1,9 +1,13 @@
 package skizze_test
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 
 	pb "github.com/skizzehq/goskizze/protobuf"
 	. "github.com/skizzehq/goskizze/skizze"
@@ -22,6 +26,22 @@ func getClient(t *testing.T) (*Client, *fakeSkizze) {
 	return c, fs
 }
 
+// getHangingClient is like getClient but the server never answers.
+func getHangingClient(t *testing.T) (*Client, *fakeSkizze) {
+	assert := assert.New(t)
+
+	fs := newFakeSkizze(grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+	<-fs.ready
+
+	c, err := Dial(fs.address, Options{Insecure: true})
+	assert.Nil(err)
+
+	return c, fs
+}
+
 func closeAll(c *Client, fs *fakeSkizze) {
 	c.Close()
 	fs.server.Stop()
@@ -346,7 +366,7 @@ func TestGetMultiMembership(t *testing.T) {
 	fs.nextReply = &pb.GetMembershipReply{Results: []*pb.MembershipResult{r, r, r}}
 
 	values := []string{"foo", "bar", "baz"}
-	m, err := c.GetMultiMembership([]string{"mymembers", "myothermembers"}, values...)
+	m, err := c.GetMultiMembership([]string{"mymembers", "myothermembers", "mylastmembers"}, values...)
 	assert.Nil(err)
 	assert.NotNil(m)
 	assert.Equal(3, len(m))
@@ -420,7 +440,7 @@ func TestGetMultiFrequency(t *testing.T) {
 	fs.nextReply = &pb.GetFrequencyReply{Results: []*pb.FrequencyResult{r, r, r}}
 
 	values := []string{"foo", "bar", "baz"}
-	m, err := c.GetMultiFrequency([]string{"mymembers", "myothermembers"}, values...)
+	m, err := c.GetMultiFrequency([]string{"mymembers", "myothermembers", "mylastmembers"}, values...)
 	assert.Nil(err)
 	assert.NotNil(m)
 	assert.Equal(3, len(m))
@@ -487,7 +507,7 @@ func TestGetMultiRankings(t *testing.T) {
 	}
 	fs.nextReply = &pb.GetRankingsReply{Results: []*pb.RankingsResult{r, r, r}}
 
-	m, err := c.GetMultiRankings([]string{"mymembers", "myothermembers"})
+	m, err := c.GetMultiRankings([]string{"mymembers", "myothermembers", "mylastmembers"})
 	assert.Nil(err)
 	assert.NotNil(m)
 	assert.Equal(3, len(m))
@@ -550,7 +570,7 @@ func TestGetMultiCardinality(t *testing.T) {
 		},
 	}
 
-	m, err := c.GetMultiCardinality([]string{"mymembers", "myothermembers"})
+	m, err := c.GetMultiCardinality([]string{"mymembers", "myothermembers", "mylastmembers"})
 	assert.Nil(err)
 	assert.NotNil(m)
 	assert.Equal(3, len(m))
@@ -564,3 +584,213 @@ func TestGetMultiCardinality(t *testing.T) {
 	assert.Equal(pb.SketchType_CARD, req.GetSketches()[0].GetType())
 	assert.Equal(pb.SketchType_CARD, req.GetSketches()[1].GetType())
 }
+
+func TestContextCanceled(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.AddReply{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := c.AddToDomainContext(ctx, "mydomain", "one", "two")
+	assert.NotNil(err)
+	assert.Nil(fs.lastRequest)
+}
+
+func TestGetCardinalityContext(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	thou := int64(1000)
+	fs.nextReply = &pb.GetCardinalityReply{
+		Results: []*pb.CardinalityResult{
+			&pb.CardinalityResult{
+				Cardinality: &thou,
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	card, err := c.GetCardinalityContext(ctx, "mymembers")
+	assert.Nil(err)
+	assert.Equal(thou, card)
+
+	req := fs.lastRequest.(*pb.GetRequest)
+	assert.Equal("mymembers", req.GetSketches()[0].GetName())
+}
+
+func TestCreateSnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	status := pb.SnapshotStatus_IN_PROGRESS
+	fs.nextReply = &pb.CreateSnapshotReply{Status: &status, StatusMessage: stringp("started")}
+
+	s, err := c.CreateSnapshot()
+	assert.Nil(err)
+	assert.NotNil(s)
+	assert.Equal(InProgress, s.Status)
+	assert.Equal("started", s.Message)
+
+	_, ok := fs.lastRequest.(*pb.CreateSnapshotRequest)
+	assert.True(ok)
+}
+
+func TestGetSnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	status := pb.SnapshotStatus_SUCCESSFUL
+	ts := int64(1456000000)
+	fs.nextReply = &pb.GetSnapshotReply{Status: &status, StatusMessage: stringp("done"), Timestamp: &ts}
+
+	s, err := c.GetSnapshot()
+	assert.Nil(err)
+	assert.NotNil(s)
+	assert.Equal(Successful, s.Status)
+	assert.Equal("done", s.Message)
+	assert.Equal(time.Unix(ts, 0), s.Timestamp)
+
+	_, ok := fs.lastRequest.(*pb.GetSnapshotRequest)
+	assert.True(ok)
+}
+
+func TestWaitForSnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	status := pb.SnapshotStatus_FAILED
+	fs.nextReply = &pb.GetSnapshotReply{Status: &status, StatusMessage: stringp("disk full")}
+
+	s, err := c.WaitForSnapshot(context.Background())
+	assert.Nil(err)
+	assert.NotNil(s)
+	assert.Equal(Failed, s.Status)
+	assert.Equal("disk full", s.Message)
+	assert.True(s.Timestamp.IsZero())
+}
+
+func TestWaitForSnapshotTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	status := pb.SnapshotStatus_IN_PROGRESS
+	fs.nextReply = &pb.GetSnapshotReply{Status: &status}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	s, err := c.WaitForSnapshot(ctx)
+	assert.Equal(context.DeadlineExceeded, err)
+	assert.NotNil(s)
+	assert.Equal(InProgress, s.Status)
+}
+
+func TestGetSketchState(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	st := pb.SketchType_MEMB
+	fill := float32(0.95)
+	ts := int64(1456000000)
+	fs.nextReply = &pb.Sketch{
+		Name:  stringp("mysketchman"),
+		Type:  &st,
+		State: &pb.SketchState{FillRate: &fill, LastSnapshot: &ts},
+	}
+
+	d, err := c.GetSketch("mysketchman", Membership)
+	assert.Nil(err)
+	assert.NotNil(d)
+	assert.NotNil(d.State)
+	assert.Equal(fill, d.State.FillRate)
+	assert.Equal(time.Unix(ts, 0), d.State.LastSnapshot)
+}
+
+func TestGetDomainState(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	memb := pb.SketchType_MEMB
+	card := pb.SketchType_CARD
+	fill := float32(0.5)
+	fs.nextReply = &pb.Domain{
+		Name: stringp("mydomainman"),
+		Sketches: []*pb.Sketch{
+			&pb.Sketch{Name: stringp("mydomainman"), Type: &memb, State: &pb.SketchState{FillRate: &fill}},
+			&pb.Sketch{Name: stringp("mydomainman"), Type: &card},
+		},
+	}
+
+	d, err := c.GetDomain("mydomainman")
+	assert.Nil(err)
+	assert.NotNil(d)
+	assert.Equal(2, len(d.Sketches))
+	assert.Equal(fill, d.Sketches[0].State.FillRate)
+	assert.True(d.Sketches[0].State.LastSnapshot.IsZero())
+	assert.Nil(d.Sketches[1].State)
+}
+
+func TestListAllUnknownType(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	types := []pb.SketchType{pb.SketchType_MEMB, pb.SketchType(99)}
+	fs.nextReply = &pb.ListReply{
+		Sketches: []*pb.Sketch{
+			&pb.Sketch{Name: stringp("foobar"), Type: &types[0]},
+			&pb.Sketch{Name: stringp("fromthefuture"), Type: &types[1]},
+		},
+	}
+
+	sketches, err := c.ListAll()
+	assert.Nil(err)
+	assert.Equal(2, len(sketches))
+	assert.Equal(Membership, sketches[0].Type)
+	assert.Equal(UnknownType, sketches[1].Type)
+
+	// Sketch types are encoded by name, including unknown ones.
+	data, err := json.Marshal(sketches)
+	assert.Nil(err)
+	assert.Equal(`[{"Name":"foobar","Type":"membership","Properties":null,"State":null},`+
+		`{"Name":"fromthefuture","Type":"unknown","Properties":null,"State":null}]`, string(data))
+	var decoded []*Sketch
+	assert.Nil(json.Unmarshal(data, &decoded))
+	assert.Equal(sketches, decoded)
+}
+
+func TestGetSnapshotUnknownState(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	status := pb.SnapshotStatus(99)
+	fs.nextReply = &pb.GetSnapshotReply{Status: &status}
+
+	s, err := c.GetSnapshot()
+	assert.Nil(err)
+	assert.Equal(UnknownState, s.Status)
+}
@@ -75,6 +75,15 @@
 //
 //    CARD: There are 3 items in the testdomain domain
 //
+// Every Client method has a Context variant (e.g. AddToDomainContext) which accepts a
+// context.Context, allowing callers to set deadlines or cancel in-flight requests.
+//
+// NewLocal returns an in-process engine with the same methods as Client, which can be
+// used in tests or where no Skizze server is available.
+//
+// Domains and sketches can also be declared in a YAML or JSON Schema and created with
+// Apply, which compares the schema with Skizze and can delete what is not declared.
+//
 // For a full guide, visit https://github.com/skizzehq/goskizze.
 //
 package skizze
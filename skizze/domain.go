@@ -12,9 +12,9 @@ type Domain struct {
 
 // DomainProperties details configuration for Sketches in a domain
 type DomainProperties struct {
-	MembershipProperties Properties
-	FrequencyProperties  Properties
-	RankingsProperties   Properties
+	MembershipProperties Properties `json:"membership" yaml:"membership"`
+	FrequencyProperties  Properties `json:"frequency" yaml:"frequency"`
+	RankingsProperties   Properties `json:"rankings" yaml:"rankings"`
 }
 
 func newDomainFromRaw(d *pb.Domain) *Domain {
@@ -0,0 +1,89 @@
+package skizze
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrNotFound is returned when the requested domain or sketch does not exist.
+	ErrNotFound = errors.New("skizze: not found")
+	// ErrAlreadyExists is returned when creating a domain or sketch that already exists.
+	ErrAlreadyExists = errors.New("skizze: already exists")
+	// ErrUnavailable is returned when Skizze cannot be reached.
+	ErrUnavailable = errors.New("skizze: unavailable")
+	// ErrInvalidProperties is returned when Skizze rejects the request arguments, such as
+	// the Properties of a new sketch.
+	ErrInvalidProperties = errors.New("skizze: invalid properties")
+	// ErrInvalidSketchType is returned when a SketchType argument is not one of the known
+	// sketch types.
+	ErrInvalidSketchType = errors.New("skizze: invalid sketch type")
+	// ErrMalformedReply is returned when a reply from Skizze does not match the request,
+	// e.g. it has fewer results than sketches queried.
+	ErrMalformedReply = errors.New("skizze: malformed reply")
+)
+
+// Error is returned by Client methods when a request fails. Use errors.Is to test it
+// against the Err* sentinels, or errors.As to inspect the gRPC status code.
+type Error struct {
+	// Op is the operation that failed, e.g. "CreateDomain".
+	Op string
+	// Code is the gRPC status code of the failure, or codes.Unknown if the error did not
+	// come from Skizze.
+	Code codes.Code
+	// Err is the underlying error.
+	Err error
+}
+
+func newError(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Op: op, Code: status.Code(err), Err: err}
+}
+
+// newClientError wraps a failure detected by the client rather than reported by Skizze,
+// such as an invalid argument or a malformed reply.
+func newClientError(op string, err error) error {
+	return &Error{Op: op, Code: codes.Unknown, Err: err}
+}
+
+func newDialError(address string, err error) error {
+	return &Error{
+		Op:   "Dial",
+		Code: status.Code(err),
+		Err:  fmt.Errorf("Unable to dial Skizze at %v: %v", address, err),
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Op + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error's status code corresponds to target.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Code == codes.NotFound
+	case ErrAlreadyExists:
+		return e.Code == codes.AlreadyExists
+	case ErrUnavailable:
+		return e.Code == codes.Unavailable
+	case ErrInvalidProperties:
+		return e.Code == codes.InvalidArgument || e.Code == codes.OutOfRange
+	case context.Canceled:
+		return e.Code == codes.Canceled
+	case context.DeadlineExceeded:
+		return e.Code == codes.DeadlineExceeded
+	}
+	return false
+}
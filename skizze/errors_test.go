@@ -0,0 +1,152 @@
+package skizze_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/skizzehq/goskizze/protobuf"
+	. "github.com/skizzehq/goskizze/skizze"
+)
+
+func TestErrorNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.Domain{}
+	fs.nextError = status.Error(codes.NotFound, "domain mydomain does not exist")
+
+	d, err := c.GetDomain("mydomain")
+	assert.Nil(d)
+	assert.True(errors.Is(err, ErrNotFound))
+	assert.False(errors.Is(err, ErrAlreadyExists))
+
+	var serr *Error
+	assert.True(errors.As(err, &serr))
+	assert.Equal("GetDomain", serr.Op)
+	assert.Equal(codes.NotFound, serr.Code)
+	assert.Equal(codes.NotFound, status.Code(errors.Unwrap(err)))
+}
+
+func TestErrorAlreadyExists(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.Domain{}
+	fs.nextError = status.Error(codes.AlreadyExists, "domain mydomain exists")
+
+	_, err := c.CreateDomain("mydomain")
+	assert.True(errors.Is(err, ErrAlreadyExists))
+	assert.False(errors.Is(err, ErrNotFound))
+}
+
+func TestErrorInvalidProperties(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.Sketch{}
+	fs.nextError = status.Error(codes.InvalidArgument, "error rate out of range")
+
+	_, err := c.CreateSketch("mysketch", Membership, &Properties{ErrorRate: 2})
+	assert.True(errors.Is(err, ErrInvalidProperties))
+}
+
+func TestErrorUnavailable(t *testing.T) {
+	assert := assert.New(t)
+
+	// Nothing listens on port 1, so requests fail fast as unavailable.
+	c, err := Dial("127.0.0.1:1", Options{Insecure: true})
+	assert.Nil(err)
+	defer c.Close()
+
+	err = c.AddToDomain("mydomain", "one")
+	assert.True(errors.Is(err, ErrUnavailable))
+}
+
+func TestErrorCanceled(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.ListDomainsContext(ctx)
+	assert.True(errors.Is(err, context.Canceled))
+}
+
+func TestInvalidSketchType(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	_, err := c.CreateSketch("mysketch", SketchType(42), &Properties{})
+	assert.True(errors.Is(err, ErrInvalidSketchType))
+	assert.Nil(fs.lastRequest)
+
+	err = c.AddToSketch("mysketch", UnknownType, "one")
+	assert.True(errors.Is(err, ErrInvalidSketchType))
+	assert.Nil(fs.lastRequest)
+
+	var serr *Error
+	assert.True(errors.As(err, &serr))
+	assert.Equal("AddToSketch", serr.Op)
+}
+
+func TestMalformedReply(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.GetMembershipReply{}
+	_, err := c.GetMembership("mymembers", "one")
+	assert.True(errors.Is(err, ErrMalformedReply))
+
+	fs.nextReply = &pb.GetFrequencyReply{Results: []*pb.FrequencyResult{&pb.FrequencyResult{}}}
+	_, err = c.GetFrequency("myfreqs", "one", "two")
+	assert.True(errors.Is(err, ErrMalformedReply))
+
+	fs.nextReply = &pb.GetRankingsReply{}
+	_, err = c.GetRankings("myranks")
+	assert.True(errors.Is(err, ErrMalformedReply))
+
+	fs.nextReply = &pb.GetCardinalityReply{}
+	_, err = c.GetCardinality("mycard")
+	assert.True(errors.Is(err, ErrMalformedReply))
+
+	thou := int64(1000)
+	fs.nextReply = &pb.GetCardinalityReply{Results: []*pb.CardinalityResult{&pb.CardinalityResult{Cardinality: &thou}}}
+	_, err = c.GetMultiCardinality([]string{"one", "two"})
+	assert.True(errors.Is(err, ErrMalformedReply))
+
+	var serr *Error
+	assert.True(errors.As(err, &serr))
+	assert.Equal("GetMultiCardinality", serr.Op)
+}
+
+func TestGetMultiRankingsError(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.GetRankingsReply{}
+	fs.nextError = status.Error(codes.NotFound, "no such sketch")
+
+	m, err := c.GetMultiRankings([]string{"one", "two"})
+	assert.Nil(m)
+	assert.True(errors.Is(err, ErrNotFound))
+}
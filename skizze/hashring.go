@@ -0,0 +1,75 @@
+package skizze
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// defaultVirtualNodes is the number of points each node has on a hashRing.
+const defaultVirtualNodes = 128
+
+// hashRing maps names to nodes by consistent hashing: each node is placed at several
+// points of a ring of hashes, and a name belongs to the node of the first point at or
+// after its hash. Adding a node only moves the names which fall next to its points.
+type hashRing struct {
+	virtual int
+	points  []uint64
+	owners  map[uint64]string
+}
+
+func newHashRing(virtual int) *hashRing {
+	if virtual <= 0 {
+		virtual = defaultVirtualNodes
+	}
+	return &hashRing{virtual: virtual, owners: make(map[uint64]string)}
+}
+
+// clone returns a copy of the ring, so that a change can be planned before it is made.
+func (r *hashRing) clone() *hashRing {
+	c := &hashRing{
+		virtual: r.virtual,
+		points:  append([]uint64(nil), r.points...),
+		owners:  make(map[uint64]string, len(r.owners)),
+	}
+	for p, n := range r.owners {
+		c.owners[p] = n
+	}
+	return c
+}
+
+func (r *hashRing) add(node string) {
+	for i := 0; i < r.virtual; i++ {
+		p := ringHash(node + "#" + strconv.Itoa(i))
+		if _, ok := r.owners[p]; ok {
+			continue
+		}
+		r.owners[p] = node
+		r.points = append(r.points, p)
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+}
+
+// get returns the node owning name. The ring must not be empty.
+func (r *hashRing) get(name string) string {
+	h := ringHash(name)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[r.points[i]]
+}
+
+// ringHash is FNV-1a followed by the splitmix64 finalizer, as FNV alone spreads similar
+// keys such as "node#1" and "node#2" poorly.
+func ringHash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,350 @@
+package skizze
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+var defaultProgressInterval = 10000
+
+// Target identifies the domain or sketch ingested values are added to.
+type Target struct {
+	// Domain is the name of a domain. If it is empty, values are added to the sketch
+	// named Sketch of type Type. If both are empty, only records which name their own
+	// domain are added.
+	Domain string
+	Sketch string
+	Type   SketchType
+}
+
+// withName returns the target of the same kind as t named name.
+func (t Target) withName(name string) Target {
+	if t.Domain != "" || t.Sketch == "" {
+		return Target{Domain: name}
+	}
+	return Target{Sketch: name, Type: t.Type}
+}
+
+// IngestOptions configures the ingestion of values.
+type IngestOptions struct {
+	// Batch configures the BatchWriter used to add values. Its OnError is called for
+	// every failed batch.
+	Batch BatchOptions
+
+	// Progress, if set, is called after every ProgressInterval records and once when
+	// ingestion ends.
+	Progress func(IngestStats)
+	// ProgressInterval defaults to 10000 records.
+	ProgressInterval int
+}
+
+// IngestStats counts the records ingested.
+type IngestStats struct {
+	// Records is the number of records read.
+	Records int64
+	// Values is the number of values read and handed to the BatchWriter, including
+	// those counted in Failed.
+	Values int64
+	// Skipped is the number of records which were malformed or had no value.
+	Skipped int64
+	// Failed is the number of values Skizze failed to add.
+	Failed int64
+}
+
+// CSVOptions configures IngestCSV.
+type CSVOptions struct {
+	IngestOptions
+
+	// Comma is the field delimiter. Defaults to ','.
+	Comma rune
+	// Header indicates the first record names the columns.
+	Header bool
+	// ValueColumn selects the column holding the value, by name if Header is set or by
+	// zero-based index. Defaults to the first column.
+	ValueColumn string
+	// TargetColumn, if set, selects a column holding the name of the domain, or of the
+	// sketch of the target's type, each value is added to. Records with an empty target
+	// are added to the target passed to IngestCSV.
+	TargetColumn string
+}
+
+// JSONOptions configures IngestJSONLines.
+type JSONOptions struct {
+	IngestOptions
+
+	// ValuePath is the dot-separated path of the value in each object, e.g. "user.id".
+	// Strings, numbers and booleans are added as text; each element of an array is added
+	// as a value.
+	ValuePath string
+	// TargetPath, if set, is the path of the name of the domain, or of the sketch of the
+	// target's type, each value is added to. Objects without it are added to the target
+	// passed to IngestJSONLines.
+	TargetPath string
+}
+
+// IngestLines adds each non-empty line read from r as a value of target, in batches. It
+// returns when r is exhausted, ctx is done or r returns an error. The returned error is
+// the first error reading r or adding values; the stats count the values added until then.
+func (c *Client) IngestLines(ctx context.Context, r io.Reader, target Target, opts IngestOptions) (IngestStats, error) {
+	br := bufio.NewReader(r)
+	return c.ingest(ctx, "IngestLines", target, opts, func() (*record, error) {
+		line, err := br.ReadString('\n')
+		if err == io.EOF && line != "" {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			return &record{}, nil
+		}
+		return &record{values: []string{line}}, nil
+	})
+}
+
+// IngestCSV adds a column of each CSV record read from r as a value, in batches. Records
+// which cannot be parsed or lack the value column are skipped. It otherwise behaves like
+// IngestLines.
+func (c *Client) IngestCSV(ctx context.Context, r io.Reader, target Target, opts CSVOptions) (IngestStats, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+
+	var header []string
+	if opts.Header {
+		var err error
+		if header, err = cr.Read(); err == io.EOF {
+			return IngestStats{}, nil
+		} else if err != nil {
+			return IngestStats{}, newClientError("IngestCSV", err)
+		}
+	}
+	valueCol, err := csvColumn(header, opts.ValueColumn, 0)
+	if err != nil {
+		return IngestStats{}, newClientError("IngestCSV", err)
+	}
+	targetCol, err := csvColumn(header, opts.TargetColumn, -1)
+	if err != nil {
+		return IngestStats{}, newClientError("IngestCSV", err)
+	}
+
+	return c.ingest(ctx, "IngestCSV", target, opts.IngestOptions, func() (*record, error) {
+		fields, err := cr.Read()
+		if _, ok := err.(*csv.ParseError); ok {
+			return &record{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if valueCol >= len(fields) {
+			return &record{}, nil
+		}
+		rec := &record{values: []string{fields[valueCol]}}
+		if targetCol >= 0 && targetCol < len(fields) {
+			rec.target = fields[targetCol]
+		}
+		return rec, nil
+	})
+}
+
+// csvColumn returns the index of the column named or numbered by col, or def if col is
+// empty.
+func csvColumn(header []string, col string, def int) (int, error) {
+	if col == "" {
+		return def, nil
+	}
+	for i, name := range header {
+		if name == col {
+			return i, nil
+		}
+	}
+	i, err := strconv.Atoi(col)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("unknown CSV column %q", col)
+	}
+	return i, nil
+}
+
+// IngestJSONLines adds a value from each JSON object read from r, one object per line,
+// in batches. Lines which are not valid JSON or lack the value are skipped. It otherwise
+// behaves like IngestLines.
+func (c *Client) IngestJSONLines(ctx context.Context, r io.Reader, target Target, opts JSONOptions) (IngestStats, error) {
+	if opts.ValuePath == "" {
+		return IngestStats{}, newClientError("IngestJSONLines", fmt.Errorf("no value path"))
+	}
+	valuePath := strings.Split(opts.ValuePath, ".")
+	var targetPath []string
+	if opts.TargetPath != "" {
+		targetPath = strings.Split(opts.TargetPath, ".")
+	}
+
+	br := bufio.NewReader(r)
+	return c.ingest(ctx, "IngestJSONLines", target, opts.IngestOptions, func() (*record, error) {
+		line, err := br.ReadBytes('\n')
+		if err == io.EOF && len(line) > 0 {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var obj interface{}
+		dec := json.NewDecoder(strings.NewReader(string(line)))
+		dec.UseNumber()
+		if err := dec.Decode(&obj); err != nil {
+			return &record{}, nil
+		}
+
+		rec := &record{values: jsonValues(jsonPath(obj, valuePath))}
+		if targetPath != nil {
+			if names := jsonValues(jsonPath(obj, targetPath)); len(names) == 1 {
+				rec.target = names[0]
+			}
+		}
+		return rec, nil
+	})
+}
+
+func jsonPath(v interface{}, path []string) interface{} {
+	for _, key := range path {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = obj[key]
+	}
+	return v
+}
+
+func jsonValues(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case json.Number:
+		return []string{v.String()}
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	case []interface{}:
+		var ret []string
+		for _, e := range v {
+			switch e.(type) {
+			case string, json.Number, bool:
+				ret = append(ret, jsonValues(e)...)
+			}
+		}
+		return ret
+	}
+	return nil
+}
+
+// record is a parsed input record. A record without values is skipped.
+type record struct {
+	values []string
+	// target is the name of the domain or sketch the values are added to, or empty for
+	// the default target.
+	target string
+}
+
+// ingest adds the records returned by next until it returns an error, which is io.EOF
+// at the end of the input.
+func (c *Client) ingest(ctx context.Context, op string, target Target, opts IngestOptions, next func() (*record, error)) (IngestStats, error) {
+	if target.Sketch != "" {
+		if _, err := getRawSketchForSketchType(target.Type); err != nil {
+			return IngestStats{}, newClientError(op, err)
+		}
+	}
+	interval := opts.ProgressInterval
+	if interval <= 0 {
+		interval = defaultProgressInterval
+	}
+
+	var mu sync.Mutex
+	var stats IngestStats
+	var failed error
+	batchOpts := opts.Batch
+	batchOpts.OnError = func(err *BatchError) {
+		mu.Lock()
+		stats.Failed += int64(len(err.Values))
+		if failed == nil {
+			failed = err
+		}
+		mu.Unlock()
+		if opts.Batch.OnError != nil {
+			opts.Batch.OnError(err)
+		}
+	}
+	snapshot := func() IngestStats {
+		mu.Lock()
+		defer mu.Unlock()
+		return stats
+	}
+
+	w := c.NewBatchWriter(batchOpts)
+	var err error
+	for err == nil {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		var rec *record
+		if rec, err = next(); err != nil {
+			break
+		}
+
+		t := target
+		if rec.target != "" {
+			t = target.withName(rec.target)
+		}
+		if t.Domain == "" && t.Sketch == "" {
+			rec.values = nil
+		}
+		if len(rec.values) > 0 {
+			if t.Domain != "" {
+				err = w.AddToDomain(t.Domain, rec.values...)
+			} else {
+				err = w.AddToSketch(t.Sketch, t.Type, rec.values...)
+			}
+		}
+
+		mu.Lock()
+		stats.Records++
+		if len(rec.values) == 0 {
+			stats.Skipped++
+		}
+		stats.Values += int64(len(rec.values))
+		progress := stats.Records%int64(interval) == 0
+		mu.Unlock()
+		if progress && opts.Progress != nil {
+			opts.Progress(snapshot())
+		}
+	}
+	if err == io.EOF {
+		err = nil
+	} else if err != nil {
+		err = newClientError(op, err)
+	}
+
+	// Once ctx is done, values still buffered or in flight are counted as failed.
+	w.CloseContext(ctx)
+	ret := snapshot()
+	if opts.Progress != nil {
+		opts.Progress(ret)
+	}
+	if err == nil {
+		mu.Lock()
+		err = failed
+		mu.Unlock()
+	}
+	return ret, err
+}
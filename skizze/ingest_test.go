@@ -0,0 +1,133 @@
+package skizze_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/skizzehq/goskizze/protobuf"
+	. "github.com/skizzehq/goskizze/skizze"
+)
+
+func TestIngestLines(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+	fs.nextReply = &pb.AddReply{}
+
+	var progress []IngestStats
+	stats, err := c.IngestLines(context.Background(), strings.NewReader("alvin\nsimon\r\n\ntheodore"),
+		Target{Domain: "mydomain"}, IngestOptions{
+			Batch:            BatchOptions{FlushInterval: time.Hour},
+			Progress:         func(s IngestStats) { progress = append(progress, s) },
+			ProgressInterval: 2,
+		})
+	assert.Nil(err)
+	assert.Equal(IngestStats{Records: 4, Values: 3, Skipped: 1}, stats)
+	assert.Equal([]IngestStats{{Records: 2, Values: 2}, {Records: 4, Values: 3, Skipped: 1}, stats}, progress)
+	assert.Equal([]string{"alvin", "simon", "theodore"}, addedValues(fs)["mydomain"])
+}
+
+func TestIngestLinesCancel(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getHangingClient(t)
+	defer closeAll(c, fs)
+
+	// Buffered values are not waited for once ctx is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	stats, err := c.IngestLines(ctx, strings.NewReader("alvin\nsimon\n"), Target{Domain: "mydomain"},
+		IngestOptions{Batch: BatchOptions{FlushInterval: time.Hour}})
+	assert.True(errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(IngestStats{Records: 2, Values: 2, Failed: 2}, stats)
+}
+
+func TestIngestCSV(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+	fs.nextReply = &pb.AddReply{}
+
+	input := "id;user;page\n1;alvin;home\n2;simon\n3;theodore;about\n4;\"bad\n"
+	stats, err := c.IngestCSV(context.Background(), strings.NewReader(input),
+		Target{Sketch: "users", Type: Cardinality}, CSVOptions{
+			Comma:        ';',
+			Header:       true,
+			ValueColumn:  "user",
+			TargetColumn: "page",
+		})
+	assert.Nil(err)
+	assert.Equal(IngestStats{Records: 4, Values: 3, Skipped: 1}, stats)
+
+	added := addedValues(fs)
+	assert.Equal([]string{"alvin"}, added["home"])
+	assert.Equal([]string{"simon"}, added["users"])
+	assert.Equal([]string{"theodore"}, added["about"])
+	for _, r := range fs.allRequests() {
+		if req, ok := r.(*pb.AddRequest); ok {
+			assert.Equal(pb.SketchType_CARD, req.GetSketch().GetType())
+		}
+	}
+
+	_, err = c.IngestCSV(context.Background(), strings.NewReader("a,b\n"), Target{Domain: "d"},
+		CSVOptions{Header: true, ValueColumn: "c"})
+	assert.NotNil(err)
+}
+
+func TestIngestJSONLines(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+	fs.nextReply = &pb.AddReply{}
+
+	input := `{"user": {"id": 1}, "tags": ["a", "b"], "domain": "d1"}
+{"user": {"id": "simon"}}
+{"user": {"name": "theodore"}}
+not json
+{"user": {"id": true}, "domain": "d2"}`
+	stats, err := c.IngestJSONLines(context.Background(), strings.NewReader(input), Target{Domain: "users"},
+		JSONOptions{ValuePath: "user.id", TargetPath: "domain"})
+	assert.Nil(err)
+	assert.Equal(IngestStats{Records: 5, Values: 3, Skipped: 2}, stats)
+
+	added := addedValues(fs)
+	assert.Equal([]string{"1"}, added["d1"])
+	assert.Equal([]string{"simon"}, added["users"])
+	assert.Equal([]string{"true"}, added["d2"])
+
+	_, err = c.IngestJSONLines(context.Background(), strings.NewReader(`{"tags": ["a", "b"]}`), Target{Domain: "tags"},
+		JSONOptions{ValuePath: "tags"})
+	assert.Nil(err)
+	assert.Equal([]string{"a", "b"}, addedValues(fs)["tags"])
+}
+
+func TestIngestErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+	fs.nextReply = &pb.AddReply{}
+	fs.nextError = status.Error(codes.NotFound, "no such domain")
+
+	stats, err := c.IngestLines(context.Background(), strings.NewReader("a\nb\n"), Target{Domain: "mydomain"}, IngestOptions{})
+	assert.True(errors.Is(err, ErrNotFound))
+	assert.Equal(IngestStats{Records: 2, Values: 2, Failed: 2}, stats)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.IngestLines(ctx, strings.NewReader("a\n"), Target{Domain: "mydomain"}, IngestOptions{})
+	assert.True(errors.Is(err, context.Canceled))
+
+	_, err = c.IngestLines(context.Background(), strings.NewReader("a\n"), Target{Sketch: "s", Type: SketchType(42)}, IngestOptions{})
+	assert.True(errors.Is(err, ErrInvalidSketchType))
+}
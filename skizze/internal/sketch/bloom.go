@@ -0,0 +1,60 @@
+package sketch
+
+import (
+	"math"
+)
+
+// Bloom is a Bloom filter answering set membership with no false negatives and a
+// bounded false positive rate.
+type Bloom struct {
+	bits []uint64
+	m    uint64
+	k    uint64
+	set  uint64
+}
+
+// NewBloom returns a Bloom filter sized to hold n items with a false positive rate of p.
+func NewBloom(n int64, p float64) *Bloom {
+	if n < 1 {
+		n = 1
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	if m < 64 {
+		m = 64
+	}
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return &Bloom{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+// Add adds v to the filter.
+func (b *Bloom) Add(v string) {
+	h1, h2 := hashes(v)
+	for i := uint64(0); i < b.k; i++ {
+		bit := (h1 + i*h2) % b.m
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if b.bits[word]&mask == 0 {
+			b.bits[word] |= mask
+			b.set++
+		}
+	}
+}
+
+// Contains reports whether v may have been added to the filter.
+func (b *Bloom) Contains(v string) bool {
+	h1, h2 := hashes(v)
+	for i := uint64(0); i < b.k; i++ {
+		bit := (h1 + i*h2) % b.m
+		if b.bits[bit/64]&(uint64(1)<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// FillRate is the fraction of bits set, from 0.0 to 1.0.
+func (b *Bloom) FillRate() float32 {
+	return float32(b.set) / float32(b.m)
+}
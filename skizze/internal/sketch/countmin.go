@@ -0,0 +1,47 @@
+package sketch
+
+import (
+	"math"
+)
+
+// CountMin is a Count-Min sketch estimating the frequency of items. Estimates never
+// undercount, and overcount by at most epsilon times the number of items added with
+// probability 1 - epsilon.
+type CountMin struct {
+	counts [][]int64
+	width  uint64
+}
+
+// NewCountMin returns a Count-Min sketch with error factor epsilon.
+func NewCountMin(epsilon float64) *CountMin {
+	width := uint64(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / epsilon)))
+	if depth < 1 {
+		depth = 1
+	}
+	counts := make([][]int64, depth)
+	for i := range counts {
+		counts[i] = make([]int64, width)
+	}
+	return &CountMin{counts: counts, width: width}
+}
+
+// Add increments the count of v by one.
+func (c *CountMin) Add(v string) {
+	h1, h2 := hashes(v)
+	for i := range c.counts {
+		c.counts[i][(h1+uint64(i)*h2)%c.width]++
+	}
+}
+
+// Count returns the estimated count of v.
+func (c *CountMin) Count(v string) int64 {
+	h1, h2 := hashes(v)
+	min := int64(math.MaxInt64)
+	for i := range c.counts {
+		if n := c.counts[i][(h1+uint64(i)*h2)%c.width]; n < min {
+			min = n
+		}
+	}
+	return min
+}
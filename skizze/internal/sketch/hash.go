@@ -0,0 +1,31 @@
+// Package sketch implements the probabilistic data structures used by the in-process
+// Skizze engine.
+package sketch
+
+import (
+	"hash/fnv"
+)
+
+// hash64 returns a well mixed 64 bit hash of v.
+func hash64(v string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(v))
+	return mix64(h.Sum64())
+}
+
+// mix64 is the MurmurHash3 finalizer, used to spread the bits of a weak hash.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// hashes returns two independent hashes of v for double hashing.
+func hashes(v string) (uint64, uint64) {
+	h1 := hash64(v)
+	h2 := mix64(h1 ^ 0x9e3779b97f4a7c15)
+	return h1, h2 | 1
+}
@@ -0,0 +1,66 @@
+package sketch
+
+import (
+	"math"
+	"math/bits"
+)
+
+// HyperLogLog estimates the number of distinct items added to it.
+type HyperLogLog struct {
+	p         uint8
+	registers []uint8
+}
+
+// NewHyperLogLog returns a HyperLogLog with 2^p registers. p is clamped to [4, 16]; the
+// standard error of the estimate is about 1.04/sqrt(2^p).
+func NewHyperLogLog(p uint8) *HyperLogLog {
+	if p < 4 {
+		p = 4
+	}
+	if p > 16 {
+		p = 16
+	}
+	return &HyperLogLog{p: p, registers: make([]uint8, 1<<p)}
+}
+
+// Add adds v to the set.
+func (h *HyperLogLog) Add(v string) {
+	x := hash64(v)
+	idx := x >> (64 - h.p)
+	rank := uint8(bits.LeadingZeros64(x<<h.p|1<<(h.p-1))) + 1
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Count returns the estimated number of distinct items.
+func (h *HyperLogLog) Count() int64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	var alpha float64
+	switch len(h.registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+
+	est := alpha * m * m / sum
+	if est <= 2.5*m && zeros > 0 {
+		// Linear counting is more accurate for small cardinalities.
+		est = m * math.Log(m/float64(zeros))
+	}
+	return int64(math.Round(est))
+}
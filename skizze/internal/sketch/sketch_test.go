@@ -0,0 +1,98 @@
+package sketch
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBloom(t *testing.T) {
+	assert := assert.New(t)
+
+	b := NewBloom(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		b.Add(strconv.Itoa(i))
+	}
+	for i := 0; i < 1000; i++ {
+		assert.True(b.Contains(strconv.Itoa(i)))
+	}
+
+	fp := 0
+	for i := 1000; i < 11000; i++ {
+		if b.Contains(strconv.Itoa(i)) {
+			fp++
+		}
+	}
+	assert.True(fp < 200, "false positives: %d", fp)
+	assert.True(b.FillRate() > 0.3 && b.FillRate() < 0.7)
+}
+
+func TestCountMin(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewCountMin(0.01)
+	for i := 0; i < 100; i++ {
+		for j := 0; j <= i; j++ {
+			c.Add(strconv.Itoa(i))
+		}
+	}
+	for i := 0; i < 100; i++ {
+		n := c.Count(strconv.Itoa(i))
+		assert.True(n >= int64(i+1))
+		assert.True(n <= int64(i+1)+50, "count of %d: %d", i, n)
+	}
+	assert.Equal(int64(0), NewCountMin(0.01).Count("missing"))
+}
+
+func TestTopK(t *testing.T) {
+	assert := assert.New(t)
+
+	k := NewTopK(3)
+	for i := 0; i < 10; i++ {
+		for j := 0; j <= i; j++ {
+			k.Add(strconv.Itoa(i))
+		}
+	}
+
+	r := k.Rankings()
+	assert.Equal(3, len(r))
+	assert.Equal("9", r[0].Value)
+	assert.Equal("8", r[1].Value)
+	assert.Equal("7", r[2].Value)
+	assert.True(r[0].Count >= 10)
+	assert.Equal(float32(1), k.FillRate())
+}
+
+func TestTopKEviction(t *testing.T) {
+	assert := assert.New(t)
+
+	k := NewTopK(2)
+	for _, v := range []string{"a", "a", "b", "c"} {
+		k.Add(v)
+	}
+	// c replaced b, inheriting its count.
+	assert.Equal([]Rank{{"a", 2}, {"c", 2}}, k.Rankings())
+
+	// Of the least frequent items, the greatest value is evicted.
+	k.Add("d")
+	assert.Equal([]Rank{{"d", 3}, {"a", 2}}, k.Rankings())
+}
+
+func TestHyperLogLog(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewHyperLogLog(14)
+	assert.Equal(int64(0), h.Count())
+
+	for _, v := range []string{"alvin", "simon", "theodore", "alvin"} {
+		h.Add(v)
+	}
+	assert.Equal(int64(3), h.Count())
+
+	for i := 0; i < 100000; i++ {
+		h.Add(strconv.Itoa(i))
+	}
+	n := h.Count()
+	assert.True(n > 97000 && n < 103000, "cardinality: %d", n)
+}
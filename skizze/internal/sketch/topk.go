@@ -0,0 +1,110 @@
+package sketch
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// TopK tracks the most frequent items using the Space-Saving algorithm. Counts of items
+// which were evicted and re-added may be overestimated.
+type TopK struct {
+	size  int
+	items map[string]*topKItem
+	// heap orders the items by ascending count, so the item to evict is first.
+	heap topKHeap
+}
+
+type topKItem struct {
+	value string
+	count int64
+	index int
+}
+
+// topKHeap is a min-heap of items by count. Of items with the same count, the greatest
+// value is evicted first.
+type topKHeap []*topKItem
+
+func (h topKHeap) Len() int { return len(h) }
+
+func (h topKHeap) Less(i, j int) bool {
+	if h[i].count != h[j].count {
+		return h[i].count < h[j].count
+	}
+	return h[i].value > h[j].value
+}
+
+func (h topKHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *topKHeap) Push(x interface{}) {
+	item := x.(*topKItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *topKHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// Rank is an item and its estimated count.
+type Rank struct {
+	Value string
+	Count int64
+}
+
+// NewTopK returns a TopK tracking the top size items.
+func NewTopK(size int64) *TopK {
+	if size < 1 {
+		size = 1
+	}
+	return &TopK{size: int(size), items: make(map[string]*topKItem)}
+}
+
+// Add increments the count of v by one.
+func (t *TopK) Add(v string) {
+	if item, ok := t.items[v]; ok {
+		item.count++
+		heap.Fix(&t.heap, item.index)
+		return
+	}
+	if len(t.items) < t.size {
+		item := &topKItem{value: v, count: 1}
+		t.items[v] = item
+		heap.Push(&t.heap, item)
+		return
+	}
+
+	// Replace the least frequent item, inheriting its count.
+	item := t.heap[0]
+	delete(t.items, item.value)
+	item.value = v
+	item.count++
+	t.items[v] = item
+	heap.Fix(&t.heap, 0)
+}
+
+// Rankings returns the tracked items ordered by descending count, then by value.
+func (t *TopK) Rankings() []Rank {
+	ret := make([]Rank, 0, len(t.items))
+	for _, item := range t.items {
+		ret = append(ret, Rank{Value: item.value, Count: item.count})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return ret[i].Value < ret[j].Value
+	})
+	return ret
+}
+
+// FillRate is the fraction of ranking slots in use, from 0.0 to 1.0.
+func (t *TopK) FillRate() float32 {
+	return float32(len(t.items)) / float32(t.size)
+}
@@ -0,0 +1,702 @@
+package skizze
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/skizzehq/goskizze/skizze/internal/sketch"
+)
+
+// defaultCardPrecision gives a standard error of about 0.8% for Cardinality sketches.
+const defaultCardPrecision = 14
+
+// Local is an in-process sketch engine with the same methods as Client, for use in
+// tests and deployments without a Skizze server. Membership sketches are Bloom filters,
+// Frequency sketches are Count-Min sketches, Ranking sketches use Space-Saving and
+// Cardinality sketches are HyperLogLogs, each sized from the sketch Properties.
+//
+// A Local is safe for concurrent use. Its data is held in memory only; snapshots are
+// recorded but not persisted.
+type Local struct {
+	mu       sync.RWMutex
+	domains  map[string]bool
+	sketches map[localKey]*localSketch
+	snapshot *Snapshot
+	// snapshotted is closed when the first snapshot is taken.
+	snapshotted chan struct{}
+}
+
+type localKey struct {
+	name string
+	typ  SketchType
+}
+
+type localSketch struct {
+	props Properties
+	state SketchState
+
+	memb *sketch.Bloom
+	freq *sketch.CountMin
+	rank *sketch.TopK
+	card *sketch.HyperLogLog
+}
+
+// NewLocal returns an empty in-process engine.
+func NewLocal() *Local {
+	return &Local{
+		domains:     make(map[string]bool),
+		sketches:    make(map[localKey]*localSketch),
+		snapshotted: make(chan struct{}),
+	}
+}
+
+func newLocalError(op string, code codes.Code, format string, args ...interface{}) error {
+	return &Error{Op: op, Code: code, Err: status.Errorf(code, format, args...)}
+}
+
+// checkContext returns an error if ctx is already done, mirroring the error a Client
+// would return.
+func checkContext(op string, ctx context.Context) error {
+	switch ctx.Err() {
+	case context.Canceled:
+		return newLocalError(op, codes.Canceled, "%v", ctx.Err())
+	case context.DeadlineExceeded:
+		return newLocalError(op, codes.DeadlineExceeded, "%v", ctx.Err())
+	}
+	return nil
+}
+
+func newLocalSketch(op string, t SketchType, p Properties) (*localSketch, error) {
+	s := &localSketch{props: p}
+	switch t {
+	case Membership, Frequency:
+		if p.MaxUniqueItems <= 0 || p.ErrorRate <= 0 || p.ErrorRate >= 1 {
+			return nil, newLocalError(op, codes.InvalidArgument,
+				"MaxUniqueItems must be positive and ErrorRate between 0 and 1, got %v and %v", p.MaxUniqueItems, p.ErrorRate)
+		}
+		if t == Membership {
+			s.memb = sketch.NewBloom(p.MaxUniqueItems, float64(p.ErrorRate))
+		} else {
+			s.freq = sketch.NewCountMin(float64(p.ErrorRate))
+		}
+	case Ranking:
+		if p.Size <= 0 {
+			return nil, newLocalError(op, codes.InvalidArgument, "Size must be positive, got %v", p.Size)
+		}
+		s.rank = sketch.NewTopK(p.Size)
+	case Cardinality:
+		precision := uint8(defaultCardPrecision)
+		if p.ErrorRate > 0 && p.ErrorRate < 1 {
+			precision = uint8(math.Ceil(2 * math.Log2(1.04/float64(p.ErrorRate))))
+		}
+		s.card = sketch.NewHyperLogLog(precision)
+	default:
+		_, err := getRawSketchForSketchType(t)
+		return nil, newClientError(op, err)
+	}
+	return s, nil
+}
+
+func (s *localSketch) add(values []string) {
+	for _, v := range values {
+		switch {
+		case s.memb != nil:
+			s.memb.Add(v)
+		case s.freq != nil:
+			s.freq.Add(v)
+		case s.rank != nil:
+			s.rank.Add(v)
+		case s.card != nil:
+			s.card.Add(v)
+		}
+	}
+	switch {
+	case s.memb != nil:
+		s.state.FillRate = s.memb.FillRate()
+	case s.rank != nil:
+		s.state.FillRate = s.rank.FillRate()
+	}
+}
+
+func (s *localSketch) toSketch(k localKey) *Sketch {
+	props := s.props
+	state := s.state
+	return &Sketch{Name: k.name, Type: k.typ, Properties: &props, State: &state}
+}
+
+// sortedSketches returns the sketches matching keep, ordered by name then type. The
+// caller must hold l.mu.
+func (l *Local) sortedSketches(keep func(localKey) bool) []*Sketch {
+	var keys []localKey
+	for k := range l.sketches {
+		if keep(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].typ < keys[j].typ
+	})
+
+	var ret []*Sketch
+	for _, k := range keys {
+		ret = append(ret, l.sketches[k].toSketch(k))
+	}
+	return ret
+}
+
+// lookup returns the sketches for names of type t, or a NotFound error. The caller must
+// hold l.mu.
+func (l *Local) lookup(op string, t SketchType, names ...string) ([]*localSketch, error) {
+	ret := make([]*localSketch, len(names))
+	for i, name := range names {
+		s, ok := l.sketches[localKey{name, t}]
+		if !ok {
+			return nil, newLocalError(op, codes.NotFound, "sketch %v does not exist", name)
+		}
+		ret[i] = s
+	}
+	return ret, nil
+}
+
+// Close is a no-op, present so Local has the same methods as Client.
+func (l *Local) Close() {}
+
+// ListAll gets all the available Sketches.
+func (l *Local) ListAll() ([]*Sketch, error) {
+	return l.ListAllContext(context.Background())
+}
+
+// ListAllContext is like ListAll but uses the supplied context.
+func (l *Local) ListAllContext(ctx context.Context) ([]*Sketch, error) {
+	if err := checkContext("ListAll", ctx); err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.sortedSketches(func(localKey) bool { return true }), nil
+}
+
+// ListSketches gets all the sketches of the specified type.
+func (l *Local) ListSketches(t SketchType) ([]*Sketch, error) {
+	return l.ListSketchesContext(context.Background(), t)
+}
+
+// ListSketchesContext is like ListSketches but uses the supplied context.
+func (l *Local) ListSketchesContext(ctx context.Context, t SketchType) ([]*Sketch, error) {
+	if _, err := getRawSketchForSketchType(t); err != nil {
+		return nil, newClientError("ListSketches", err)
+	}
+	if err := checkContext("ListSketches", ctx); err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.sortedSketches(func(k localKey) bool { return k.typ == t }), nil
+}
+
+// ListDomains gets all the available domains
+func (l *Local) ListDomains() ([]string, error) {
+	return l.ListDomainsContext(context.Background())
+}
+
+// ListDomainsContext is like ListDomains but uses the supplied context.
+func (l *Local) ListDomainsContext(ctx context.Context) ([]string, error) {
+	if err := checkContext("ListDomains", ctx); err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	ret := []string{}
+	for name := range l.domains {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// CreateDomain creates a new domain with default properties per Sketch.
+func (l *Local) CreateDomain(name string) (*Domain, error) {
+	return l.CreateDomainContext(context.Background(), name)
+}
+
+// CreateDomainContext is like CreateDomain but uses the supplied context.
+func (l *Local) CreateDomainContext(ctx context.Context, name string) (*Domain, error) {
+	return l.createDomain(ctx, "CreateDomain", name, &DomainProperties{
+		MembershipProperties: DefaultProperties(Membership),
+		FrequencyProperties:  DefaultProperties(Frequency),
+		RankingsProperties:   DefaultProperties(Ranking),
+	})
+}
+
+// CreateDomainWithProperties creates a domain with customized properties.
+func (l *Local) CreateDomainWithProperties(name string, props *DomainProperties) (*Domain, error) {
+	return l.CreateDomainWithPropertiesContext(context.Background(), name, props)
+}
+
+// CreateDomainWithPropertiesContext is like CreateDomainWithProperties but uses the
+// supplied context.
+func (l *Local) CreateDomainWithPropertiesContext(ctx context.Context, name string, props *DomainProperties) (*Domain, error) {
+	return l.createDomain(ctx, "CreateDomainWithProperties", name, props)
+}
+
+func (l *Local) createDomain(ctx context.Context, op string, name string, props *DomainProperties) (*Domain, error) {
+	if err := checkContext(op, ctx); err != nil {
+		return nil, err
+	}
+
+	types := []SketchType{Membership, Frequency, Ranking, Cardinality}
+	propsByType := []Properties{props.MembershipProperties, props.FrequencyProperties, props.RankingsProperties, {}}
+	sketches := make([]*localSketch, len(types))
+	for i, t := range types {
+		s, err := newLocalSketch(op, t, propsByType[i])
+		if err != nil {
+			return nil, err
+		}
+		sketches[i] = s
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.domains[name] {
+		return nil, newLocalError(op, codes.AlreadyExists, "domain %v already exists", name)
+	}
+	for _, t := range types {
+		if _, ok := l.sketches[localKey{name, t}]; ok {
+			return nil, newLocalError(op, codes.AlreadyExists, "sketch %v already exists", name)
+		}
+	}
+
+	l.domains[name] = true
+	ret := &Domain{Name: name}
+	for i, t := range types {
+		k := localKey{name, t}
+		l.sketches[k] = sketches[i]
+		ret.Sketches = append(ret.Sketches, sketches[i].toSketch(k))
+	}
+	return ret, nil
+}
+
+// DeleteDomain deletes a domain
+func (l *Local) DeleteDomain(name string) error {
+	return l.DeleteDomainContext(context.Background(), name)
+}
+
+// DeleteDomainContext is like DeleteDomain but uses the supplied context.
+func (l *Local) DeleteDomainContext(ctx context.Context, name string) error {
+	if err := checkContext("DeleteDomain", ctx); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.domains[name] {
+		return newLocalError("DeleteDomain", codes.NotFound, "domain %v does not exist", name)
+	}
+	delete(l.domains, name)
+	for _, t := range []SketchType{Membership, Frequency, Ranking, Cardinality} {
+		delete(l.sketches, localKey{name, t})
+	}
+	return nil
+}
+
+// GetDomain gets the details of a domain.
+func (l *Local) GetDomain(name string) (*Domain, error) {
+	return l.GetDomainContext(context.Background(), name)
+}
+
+// GetDomainContext is like GetDomain but uses the supplied context.
+func (l *Local) GetDomainContext(ctx context.Context, name string) (*Domain, error) {
+	if err := checkContext("GetDomain", ctx); err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if !l.domains[name] {
+		return nil, newLocalError("GetDomain", codes.NotFound, "domain %v does not exist", name)
+	}
+	return &Domain{
+		Name:     name,
+		Sketches: l.sortedSketches(func(k localKey) bool { return k.name == name }),
+	}, nil
+}
+
+// CreateSketch creates a new sketch.
+func (l *Local) CreateSketch(name string, t SketchType, p *Properties) (*Sketch, error) {
+	return l.CreateSketchContext(context.Background(), name, t, p)
+}
+
+// CreateSketchContext is like CreateSketch but uses the supplied context.
+func (l *Local) CreateSketchContext(ctx context.Context, name string, t SketchType, p *Properties) (*Sketch, error) {
+	if _, err := getRawSketchForSketchType(t); err != nil {
+		return nil, newClientError("CreateSketch", err)
+	}
+	if err := checkContext("CreateSketch", ctx); err != nil {
+		return nil, err
+	}
+
+	props := DefaultProperties(t)
+	if p != nil {
+		props = *p
+	}
+	s, err := newLocalSketch("CreateSketch", t, props)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	k := localKey{name, t}
+	if _, ok := l.sketches[k]; ok {
+		return nil, newLocalError("CreateSketch", codes.AlreadyExists, "sketch %v already exists", name)
+	}
+	l.sketches[k] = s
+	return s.toSketch(k), nil
+}
+
+// DeleteSketch deletes a sketch
+func (l *Local) DeleteSketch(name string, t SketchType) error {
+	return l.DeleteSketchContext(context.Background(), name, t)
+}
+
+// DeleteSketchContext is like DeleteSketch but uses the supplied context.
+func (l *Local) DeleteSketchContext(ctx context.Context, name string, t SketchType) error {
+	if _, err := getRawSketchForSketchType(t); err != nil {
+		return newClientError("DeleteSketch", err)
+	}
+	if err := checkContext("DeleteSketch", ctx); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	k := localKey{name, t}
+	if _, ok := l.sketches[k]; !ok {
+		return newLocalError("DeleteSketch", codes.NotFound, "sketch %v does not exist", name)
+	}
+	delete(l.sketches, k)
+	return nil
+}
+
+// GetSketch gets the details of a sketch.
+func (l *Local) GetSketch(name string, t SketchType) (*Sketch, error) {
+	return l.GetSketchContext(context.Background(), name, t)
+}
+
+// GetSketchContext is like GetSketch but uses the supplied context.
+func (l *Local) GetSketchContext(ctx context.Context, name string, t SketchType) (*Sketch, error) {
+	if _, err := getRawSketchForSketchType(t); err != nil {
+		return nil, newClientError("GetSketch", err)
+	}
+	if err := checkContext("GetSketch", ctx); err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	s, err := l.lookup("GetSketch", t, name)
+	if err != nil {
+		return nil, err
+	}
+	return s[0].toSketch(localKey{name, t}), nil
+}
+
+// AddToSketch will add the supplied values to the sketch's data set.
+func (l *Local) AddToSketch(name string, t SketchType, values ...string) error {
+	return l.AddToSketchContext(context.Background(), name, t, values...)
+}
+
+// AddToSketchContext is like AddToSketch but uses the supplied context.
+func (l *Local) AddToSketchContext(ctx context.Context, name string, t SketchType, values ...string) error {
+	if _, err := getRawSketchForSketchType(t); err != nil {
+		return newClientError("AddToSketch", err)
+	}
+	if err := checkContext("AddToSketch", ctx); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s, err := l.lookup("AddToSketch", t, name)
+	if err != nil {
+		return err
+	}
+	s[0].add(values)
+	return nil
+}
+
+// AddToDomain will add the supplied values to the domain's data set.
+func (l *Local) AddToDomain(name string, values ...string) error {
+	return l.AddToDomainContext(context.Background(), name, values...)
+}
+
+// AddToDomainContext is like AddToDomain but uses the supplied context.
+func (l *Local) AddToDomainContext(ctx context.Context, name string, values ...string) error {
+	if err := checkContext("AddToDomain", ctx); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.domains[name] {
+		return newLocalError("AddToDomain", codes.NotFound, "domain %v does not exist", name)
+	}
+	for _, t := range []SketchType{Membership, Frequency, Ranking, Cardinality} {
+		if s, ok := l.sketches[localKey{name, t}]; ok {
+			s.add(values)
+		}
+	}
+	return nil
+}
+
+// GetMembership queries the sketch for membership (true/false) for the provided values.
+func (l *Local) GetMembership(name string, values ...string) ([]*MembershipResult, error) {
+	return l.GetMembershipContext(context.Background(), name, values...)
+}
+
+// GetMembershipContext is like GetMembership but uses the supplied context.
+func (l *Local) GetMembershipContext(ctx context.Context, name string, values ...string) ([]*MembershipResult, error) {
+	ret, err := l.getMembership(ctx, "GetMembership", []string{name}, values)
+	if err != nil {
+		return nil, err
+	}
+	return ret[0], nil
+}
+
+// GetMultiMembership queries multiple sketches for membership of the provided values.
+func (l *Local) GetMultiMembership(names []string, values ...string) ([][]*MembershipResult, error) {
+	return l.GetMultiMembershipContext(context.Background(), names, values...)
+}
+
+// GetMultiMembershipContext is like GetMultiMembership but uses the supplied context.
+func (l *Local) GetMultiMembershipContext(ctx context.Context, names []string, values ...string) ([][]*MembershipResult, error) {
+	return l.getMembership(ctx, "GetMultiMembership", names, values)
+}
+
+func (l *Local) getMembership(ctx context.Context, op string, names []string, values []string) ([][]*MembershipResult, error) {
+	if err := checkContext(op, ctx); err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	sketches, err := l.lookup(op, Membership, names...)
+	if err != nil {
+		return nil, err
+	}
+	var ret [][]*MembershipResult
+	for _, s := range sketches {
+		r := []*MembershipResult{}
+		for _, v := range values {
+			r = append(r, &MembershipResult{Value: v, IsMember: s.memb.Contains(v)})
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}
+
+// GetFrequency queries the sketch for frequency for the provided values.
+func (l *Local) GetFrequency(name string, values ...string) ([]*FrequencyResult, error) {
+	return l.GetFrequencyContext(context.Background(), name, values...)
+}
+
+// GetFrequencyContext is like GetFrequency but uses the supplied context.
+func (l *Local) GetFrequencyContext(ctx context.Context, name string, values ...string) ([]*FrequencyResult, error) {
+	ret, err := l.getFrequency(ctx, "GetFrequency", []string{name}, values)
+	if err != nil {
+		return nil, err
+	}
+	return ret[0], nil
+}
+
+// GetMultiFrequency queries multiple sketches for the frequency of the provided values.
+func (l *Local) GetMultiFrequency(names []string, values ...string) ([][]*FrequencyResult, error) {
+	return l.GetMultiFrequencyContext(context.Background(), names, values...)
+}
+
+// GetMultiFrequencyContext is like GetMultiFrequency but uses the supplied context.
+func (l *Local) GetMultiFrequencyContext(ctx context.Context, names []string, values ...string) ([][]*FrequencyResult, error) {
+	return l.getFrequency(ctx, "GetMultiFrequency", names, values)
+}
+
+func (l *Local) getFrequency(ctx context.Context, op string, names []string, values []string) ([][]*FrequencyResult, error) {
+	if err := checkContext(op, ctx); err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	sketches, err := l.lookup(op, Frequency, names...)
+	if err != nil {
+		return nil, err
+	}
+	var ret [][]*FrequencyResult
+	for _, s := range sketches {
+		r := []*FrequencyResult{}
+		for _, v := range values {
+			r = append(r, &FrequencyResult{Value: v, Count: s.freq.Count(v)})
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}
+
+// GetRankings queries the sketch for the top rankings.
+func (l *Local) GetRankings(name string) ([]*RankingsResult, error) {
+	return l.GetRankingsContext(context.Background(), name)
+}
+
+// GetRankingsContext is like GetRankings but uses the supplied context.
+func (l *Local) GetRankingsContext(ctx context.Context, name string) ([]*RankingsResult, error) {
+	ret, err := l.getRankings(ctx, "GetRankings", []string{name})
+	if err != nil {
+		return nil, err
+	}
+	return ret[0], nil
+}
+
+// GetMultiRankings queries multiple sketches for the top rankings.
+func (l *Local) GetMultiRankings(names []string) ([][]*RankingsResult, error) {
+	return l.GetMultiRankingsContext(context.Background(), names)
+}
+
+// GetMultiRankingsContext is like GetMultiRankings but uses the supplied context.
+func (l *Local) GetMultiRankingsContext(ctx context.Context, names []string) ([][]*RankingsResult, error) {
+	return l.getRankings(ctx, "GetMultiRankings", names)
+}
+
+func (l *Local) getRankings(ctx context.Context, op string, names []string) ([][]*RankingsResult, error) {
+	if err := checkContext(op, ctx); err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	sketches, err := l.lookup(op, Ranking, names...)
+	if err != nil {
+		return nil, err
+	}
+	var ret [][]*RankingsResult
+	for _, s := range sketches {
+		r := []*RankingsResult{}
+		for _, rank := range s.rank.Rankings() {
+			r = append(r, &RankingsResult{Value: rank.Value, Count: rank.Count})
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}
+
+// GetCardinality queries the sketch for the cardinality of items.
+func (l *Local) GetCardinality(name string) (int64, error) {
+	return l.GetCardinalityContext(context.Background(), name)
+}
+
+// GetCardinalityContext is like GetCardinality but uses the supplied context.
+func (l *Local) GetCardinalityContext(ctx context.Context, name string) (int64, error) {
+	ret, err := l.getCardinality(ctx, "GetCardinality", []string{name})
+	if err != nil {
+		return 0, err
+	}
+	return ret[0], nil
+}
+
+// GetMultiCardinality queries multiple sketches for the cardinality of items.
+func (l *Local) GetMultiCardinality(names []string) ([]int64, error) {
+	return l.GetMultiCardinalityContext(context.Background(), names)
+}
+
+// GetMultiCardinalityContext is like GetMultiCardinality but uses the supplied context.
+func (l *Local) GetMultiCardinalityContext(ctx context.Context, names []string) ([]int64, error) {
+	return l.getCardinality(ctx, "GetMultiCardinality", names)
+}
+
+func (l *Local) getCardinality(ctx context.Context, op string, names []string) ([]int64, error) {
+	if err := checkContext(op, ctx); err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	sketches, err := l.lookup(op, Cardinality, names...)
+	if err != nil {
+		return nil, err
+	}
+	var ret []int64
+	for _, s := range sketches {
+		ret = append(ret, s.card.Count())
+	}
+	return ret, nil
+}
+
+// CreateSnapshot records a snapshot of all sketches. Local data is not persisted, so the
+// snapshot completes immediately.
+func (l *Local) CreateSnapshot() (*Snapshot, error) {
+	return l.CreateSnapshotContext(context.Background())
+}
+
+// CreateSnapshotContext is like CreateSnapshot but uses the supplied context.
+func (l *Local) CreateSnapshotContext(ctx context.Context) (*Snapshot, error) {
+	if err := checkContext("CreateSnapshot", ctx); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Unix(time.Now().Unix(), 0)
+	for _, s := range l.sketches {
+		s.state.LastSnapshot = now
+	}
+	if l.snapshot == nil {
+		close(l.snapshotted)
+	}
+	l.snapshot = &Snapshot{Status: Successful, Timestamp: now}
+	return &Snapshot{Status: Successful}, nil
+}
+
+// GetSnapshot gets the state of the current or previous snapshot.
+func (l *Local) GetSnapshot() (*Snapshot, error) {
+	return l.GetSnapshotContext(context.Background())
+}
+
+// GetSnapshotContext is like GetSnapshot but uses the supplied context.
+func (l *Local) GetSnapshotContext(ctx context.Context) (*Snapshot, error) {
+	if err := checkContext("GetSnapshot", ctx); err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.snapshot == nil {
+		return &Snapshot{Status: Pending}, nil
+	}
+	s := *l.snapshot
+	return &s, nil
+}
+
+// WaitForSnapshot waits until a snapshot has been taken, or until the context is done,
+// like Client.WaitForSnapshot. Local snapshots complete as soon as they are created.
+func (l *Local) WaitForSnapshot(ctx context.Context) (*Snapshot, error) {
+	s, err := l.GetSnapshotContext(ctx)
+	if err != nil || s.Status != Pending {
+		return s, err
+	}
+	select {
+	case <-ctx.Done():
+		return s, ctx.Err()
+	case <-l.snapshotted:
+		return l.GetSnapshotContext(ctx)
+	}
+}
@@ -0,0 +1,197 @@
+package skizze_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	. "github.com/skizzehq/goskizze/skizze"
+	"github.com/skizzehq/goskizze/skizze/skizzetest"
+)
+
+func TestLocalDomain(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewLocal()
+	defer l.Close()
+
+	d, err := l.CreateDomain("testdomain")
+	assert.Nil(err)
+	assert.Equal("testdomain", d.Name)
+	assert.Equal(4, len(d.Sketches))
+
+	_, err = l.CreateDomain("testdomain")
+	assert.True(errors.Is(err, ErrAlreadyExists))
+
+	assert.Nil(l.AddToDomain("testdomain", "alvin", "simon", "theodore"))
+	assert.Nil(l.AddToDomain("testdomain", "alvin", "alvin", "simon", "claire"))
+
+	membs, err := l.GetMembership("testdomain", "alvin", "claire", "gary")
+	assert.Nil(err)
+	assert.Equal(3, len(membs))
+	assert.True(membs[0].IsMember)
+	assert.True(membs[1].IsMember)
+	assert.False(membs[2].IsMember)
+
+	freqs, err := l.GetFrequency("testdomain", "alvin", "simon", "gary")
+	assert.Nil(err)
+	assert.Equal(int64(3), freqs[0].Count)
+	assert.Equal(int64(2), freqs[1].Count)
+	assert.Equal(int64(0), freqs[2].Count)
+
+	ranks, err := l.GetRankings("testdomain")
+	assert.Nil(err)
+	assert.Equal("alvin", ranks[0].Value)
+	assert.Equal(int64(3), ranks[0].Count)
+	assert.Equal("simon", ranks[1].Value)
+
+	card, err := l.GetCardinality("testdomain")
+	assert.Nil(err)
+	assert.Equal(int64(4), card)
+
+	domains, err := l.ListDomains()
+	assert.Nil(err)
+	assert.Equal([]string{"testdomain"}, domains)
+
+	d, err = l.GetDomain("testdomain")
+	assert.Nil(err)
+	assert.Equal(4, len(d.Sketches))
+	assert.True(d.Sketches[0].State.FillRate > 0)
+
+	assert.Nil(l.DeleteDomain("testdomain"))
+	_, err = l.GetDomain("testdomain")
+	assert.True(errors.Is(err, ErrNotFound))
+	all, err := l.ListAll()
+	assert.Nil(err)
+	assert.Equal(0, len(all))
+}
+
+func TestLocalSketch(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewLocal()
+
+	s, err := l.CreateSketch("top", Ranking, &Properties{Size: 2})
+	assert.Nil(err)
+	assert.Equal(int64(2), s.Properties.Size)
+
+	_, err = l.CreateSketch("bad", Membership, &Properties{MaxUniqueItems: 10, ErrorRate: 2})
+	assert.True(errors.Is(err, ErrInvalidProperties))
+
+	_, err = l.CreateSketch("bad", SketchType(42), nil)
+	assert.True(errors.Is(err, ErrInvalidSketchType))
+
+	_, err = l.CreateSketch("users", Cardinality, nil)
+	assert.Nil(err)
+
+	for i := 0; i < 5; i++ {
+		for j := 0; j <= i; j++ {
+			assert.Nil(l.AddToSketch("top", Ranking, strconv.Itoa(i)))
+		}
+		assert.Nil(l.AddToSketch("users", Cardinality, strconv.Itoa(i)))
+	}
+
+	ranks, err := l.GetRankings("top")
+	assert.Nil(err)
+	assert.Equal(2, len(ranks))
+	assert.Equal("4", ranks[0].Value)
+
+	cards, err := l.GetMultiCardinality([]string{"users", "users"})
+	assert.Nil(err)
+	assert.Equal([]int64{5, 5}, cards)
+
+	_, err = l.GetMultiCardinality([]string{"users", "nobody"})
+	assert.True(errors.Is(err, ErrNotFound))
+
+	err = l.AddToSketch("top", Frequency, "a")
+	assert.True(errors.Is(err, ErrNotFound))
+
+	sketches, err := l.ListSketches(Ranking)
+	assert.Nil(err)
+	assert.Equal(1, len(sketches))
+	assert.Equal("top", sketches[0].Name)
+
+	assert.Nil(l.DeleteSketch("top", Ranking))
+	_, err = l.GetSketch("top", Ranking)
+	assert.True(errors.Is(err, ErrNotFound))
+}
+
+func TestLocalSnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewLocal()
+	_, err := l.CreateSketch("users", Cardinality, nil)
+	assert.Nil(err)
+
+	s, err := l.GetSnapshot()
+	assert.Nil(err)
+	assert.Equal(Pending, s.Status)
+
+	_, err = l.CreateSnapshot()
+	assert.Nil(err)
+
+	s, err = l.WaitForSnapshot(context.Background())
+	assert.Nil(err)
+	assert.Equal(Successful, s.Status)
+	assert.False(s.Timestamp.IsZero())
+
+	sk, err := l.GetSketch("users", Cardinality)
+	assert.Nil(err)
+	assert.Equal(s.Timestamp, sk.State.LastSnapshot)
+}
+
+func TestLocalWaitForSnapshotMatchesClient(t *testing.T) {
+	srv := skizzetest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+	defer c.Close()
+
+	type snapshotter interface {
+		CreateSnapshot() (*Snapshot, error)
+		WaitForSnapshot(ctx context.Context) (*Snapshot, error)
+	}
+	for name, s := range map[string]snapshotter{"client": c, "local": NewLocal()} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			// Without a snapshot, WaitForSnapshot waits until the context is done.
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			snap, err := s.WaitForSnapshot(ctx)
+			assert.Equal(context.DeadlineExceeded, err)
+			assert.Equal(Pending, snap.Status)
+
+			// It returns once a snapshot is taken.
+			done := make(chan *Snapshot)
+			go func() {
+				snap, err := s.WaitForSnapshot(context.Background())
+				assert.Nil(err)
+				done <- snap
+			}()
+			time.Sleep(10 * time.Millisecond)
+			_, err = s.CreateSnapshot()
+			assert.Nil(err)
+			select {
+			case snap = <-done:
+				assert.Equal(Successful, snap.Status)
+			case <-time.After(5 * time.Second):
+				t.Fatal("WaitForSnapshot did not return")
+			}
+		})
+	}
+}
+
+func TestLocalContext(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewLocal()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := l.CreateDomainContext(ctx, "testdomain")
+	assert.True(errors.Is(err, context.Canceled))
+}
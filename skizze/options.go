@@ -1,7 +1,97 @@
 package skizze
 
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
 // Options contains connection options
 type Options struct {
 	// Insecure disables transport security for the Client connection.
 	Insecure bool
+
+	// TLSConfig is the base TLS configuration used when Insecure is false. If nil, an
+	// empty configuration is used which verifies the server against the system roots.
+	TLSConfig *tls.Config
+
+	// CAFile is the path to a PEM encoded bundle of certificate authorities used to
+	// verify the server, in place of the system roots.
+	CAFile string
+
+	// CertFile and KeyFile are the paths to a PEM encoded client certificate and key,
+	// presented to the server for mutual TLS.
+	CertFile string
+	KeyFile  string
+
+	// ServerName overrides the host name used to verify the server certificate, which
+	// is useful when connecting by IP or through a proxy.
+	ServerName string
+
+	// PerRPCCredentials are attached to every request, e.g. a BearerToken.
+	PerRPCCredentials credentials.PerRPCCredentials
+
+	// RetryPolicy, if set, retries requests which fail with a transient error.
+	RetryPolicy *RetryPolicy
+
+	// UnaryInterceptors are called, in order, around every request, e.g. for logging or
+	// tracing. When a RetryPolicy is set they are called once per attempt.
+	UnaryInterceptors []grpc.UnaryClientInterceptor
+
+	// DialOptions are passed to grpc.Dial after those derived from the other Options,
+	// e.g. for keepalive parameters, custom dialers or message size limits.
+	DialOptions []grpc.DialOption
+}
+
+func (o *Options) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{}
+	if o.TLSConfig != nil {
+		cfg = o.TLSConfig.Clone()
+	}
+
+	if o.CAFile != "" {
+		pem, err := ioutil.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read CA file %v: %v", o.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("No certificates found in CA file %v", o.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if o.CertFile != "" || o.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to load client certificate: %v", err)
+		}
+		cfg.Certificates = append(cfg.Certificates, cert)
+	}
+
+	if o.ServerName != "" {
+		cfg.ServerName = o.ServerName
+	}
+	return cfg, nil
+}
+
+// BearerToken returns PerRPCCredentials which send token in an "authorization: Bearer"
+// header with every request. The credentials require a secure connection.
+func BearerToken(token string) credentials.PerRPCCredentials {
+	return bearerToken(token)
+}
+
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return true
 }
@@ -0,0 +1,206 @@
+package skizze_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	pb "github.com/skizzehq/goskizze/protobuf"
+	. "github.com/skizzehq/goskizze/skizze"
+)
+
+// writeSelfSignedCert writes a self-signed certificate for "skizze.test", valid for both
+// server and client authentication, and returns the paths to the certificate and key.
+func writeSelfSignedCert(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "skizze.test"},
+		DNSNames:              []string{"skizze.test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	if err := ioutil.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func getTLSClient(t *testing.T, serverTLS *tls.Config, opts Options) (*Client, *fakeSkizze) {
+	assert := assert.New(t)
+
+	fs := newFakeSkizze(grpc.Creds(credentials.NewTLS(serverTLS)))
+	<-fs.ready
+
+	c, err := Dial(fs.address, opts)
+	assert.Nil(err)
+	assert.NotNil(c)
+
+	return c, fs
+}
+
+func TestDialTLS(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, _ := ioutil.TempDir("", "goskizze")
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeSelfSignedCert(t, dir)
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	assert.Nil(err)
+
+	c, fs := getTLSClient(t, &tls.Config{Certificates: []tls.Certificate{cert}}, Options{
+		CAFile:            certFile,
+		ServerName:        "skizze.test",
+		PerRPCCredentials: BearerToken("s3cr3t"),
+	})
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.ListDomainsReply{Names: []string{"dom1"}}
+
+	domains, err := c.ListDomains()
+	assert.Nil(err)
+	assert.Equal([]string{"dom1"}, domains)
+	assert.Equal([]string{"Bearer s3cr3t"}, fs.lastMetadata["authorization"])
+}
+
+func TestDialMutualTLS(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, _ := ioutil.TempDir("", "goskizze")
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeSelfSignedCert(t, dir)
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	assert.Nil(err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	assert.Nil(err)
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+
+	serverTLS := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+
+	c, fs := getTLSClient(t, serverTLS, Options{
+		CAFile:     certFile,
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		ServerName: "skizze.test",
+	})
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.ListDomainsReply{Names: []string{"dom1"}}
+
+	domains, err := c.ListDomains()
+	assert.Nil(err)
+	assert.Equal([]string{"dom1"}, domains)
+
+	// Without a client certificate the handshake is rejected.
+	c2, err := Dial(fs.address, Options{CAFile: certFile, ServerName: "skizze.test"})
+	assert.Nil(err)
+	defer c2.Close()
+
+	_, err = c2.ListDomains()
+	assert.NotNil(err)
+}
+
+func TestDialBadCAFile(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := Dial("127.0.0.1:1", Options{CAFile: "/does/not/exist.pem"})
+	assert.Nil(c)
+	assert.NotNil(err)
+}
+
+func TestDialInterceptorsAndOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	fs := newFakeSkizze()
+	<-fs.ready
+
+	var methods []string
+	c, err := Dial(fs.address, Options{
+		Insecure: true,
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				methods = append(methods, method)
+				return invoker(ctx, method, req, reply, cc, opts...)
+			},
+		},
+		DialOptions: []grpc.DialOption{grpc.WithUserAgent("goskizze-test")},
+	})
+	assert.Nil(err)
+	defer closeAll(c, fs)
+
+	fs.nextReply = &pb.ListDomainsReply{Names: []string{"dom1"}}
+
+	_, err = c.ListDomains()
+	assert.Nil(err)
+	assert.Equal([]string{"/protobuf.Skizze/ListDomains"}, methods)
+	assert.Contains(fs.lastMetadata["user-agent"][0], "goskizze-test")
+}
+
+func TestNewClientFromConn(t *testing.T) {
+	assert := assert.New(t)
+
+	fs := newFakeSkizze()
+	<-fs.ready
+	defer fs.server.Stop()
+
+	conn, err := grpc.Dial(fs.address, grpc.WithInsecure())
+	assert.Nil(err)
+	defer conn.Close()
+
+	c := NewClientFromConn(conn)
+	fs.nextReply = &pb.ListDomainsReply{Names: []string{"dom1"}}
+
+	domains, err := c.ListDomains()
+	assert.Nil(err)
+	assert.Equal([]string{"dom1"}, domains)
+
+	// Closing the client leaves the caller's connection usable.
+	c.Close()
+	_, err = NewClientFromConn(conn).ListDomains()
+	assert.Nil(err)
+}
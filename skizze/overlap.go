@@ -0,0 +1,169 @@
+package skizze
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// defaultCardErrorRate is the standard error of a Cardinality sketch created without an
+// ErrorRate, matching defaultCardPrecision.
+const defaultCardErrorRate = 0.0081
+
+// unionCleanupTimeout bounds the deletion of the temporary union sketch, which is done
+// even when the context of the estimate is done.
+var unionCleanupTimeout = 10 * time.Second
+
+// Estimate is an estimated quantity with its error bounds. Low <= Value <= High.
+type Estimate struct {
+	Value float64
+	Low   float64
+	High  float64
+}
+
+// Overlap estimates how two populations, each counted by a Cardinality sketch, overlap.
+// The bounds of A, B and Union are their estimates plus or minus the ErrorRate of their
+// sketch; the bounds of Intersection and Jaccard are the worst cases of those bounds.
+type Overlap struct {
+	// A and B are the number of distinct values in each population.
+	A, B Estimate
+	// Union is |A ∪ B|.
+	Union Estimate
+	// Intersection is |A ∩ B|, from inclusion–exclusion: |A| + |B| - |A ∪ B|.
+	Intersection Estimate
+	// Jaccard is the Jaccard similarity |A ∩ B| / |A ∪ B|, from 0 to 1.
+	Jaccard Estimate
+}
+
+// UnionSource feeds the values of both populations to add, which adds them to the union
+// sketch. It is typically the data the two sketches were built from, replayed from a log
+// or a database, as Skizze cannot merge existing sketches.
+type UnionSource func(add func(values ...string) error) error
+
+// EstimateOverlap estimates the overlap of the populations counted by the Cardinality
+// sketches a and b. The union is counted by a temporary Cardinality sketch, with the
+// properties of a, fed by union and deleted before EstimateOverlap returns. The name of
+// the temporary sketch starts with "tmp-union-".
+func EstimateOverlap(ctx context.Context, s Sketcher, a, b string, union UnionSource) (*Overlap, error) {
+	sa, err := s.GetSketchContext(ctx, a, Cardinality)
+	if err != nil {
+		return nil, err
+	}
+	sb, err := s.GetSketchContext(ctx, b, Cardinality)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("tmp-union-%s-%s-%08x", a, b, rand.Uint32())
+	su, err := s.CreateSketchContext(ctx, name, Cardinality, sa.Properties)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), unionCleanupTimeout)
+		defer cancel()
+		s.DeleteSketchContext(ctx, name, Cardinality)
+	}()
+
+	err = union(func(values ...string) error {
+		return s.AddToSketchContext(ctx, name, Cardinality, values...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if su == nil || su.Properties == nil {
+		su = sa
+	}
+	return estimateOverlap(ctx, s, []*Sketch{sa, sb, su})
+}
+
+// EstimateOverlapWithUnion is like EstimateOverlap but takes the union from the existing
+// Cardinality sketch union, which must have been fed the values of both populations.
+func EstimateOverlapWithUnion(ctx context.Context, s Sketcher, a, b, union string) (*Overlap, error) {
+	sketches := make([]*Sketch, 3)
+	for i, name := range []string{a, b, union} {
+		sk, err := s.GetSketchContext(ctx, name, Cardinality)
+		if err != nil {
+			return nil, err
+		}
+		sketches[i] = sk
+	}
+	return estimateOverlap(ctx, s, sketches)
+}
+
+// estimateOverlap queries the sketches of a, b and their union, in that order.
+func estimateOverlap(ctx context.Context, s Sketcher, sketches []*Sketch) (*Overlap, error) {
+	names := make([]string, len(sketches))
+	for i, sk := range sketches {
+		names[i] = sk.Name
+	}
+	cards, err := s.GetMultiCardinalityContext(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	if len(cards) != len(names) {
+		return nil, newClientError("GetMultiCardinality", fmt.Errorf("%w: %d results for %d sketches",
+			ErrMalformedReply, len(cards), len(names)))
+	}
+
+	var est [3]Estimate
+	for i, sk := range sketches {
+		est[i] = cardEstimate(cards[i], sk.Properties)
+	}
+	return newOverlap(est[0], est[1], est[2]), nil
+}
+
+func cardEstimate(card int64, p *Properties) Estimate {
+	e := defaultCardErrorRate
+	if p != nil && p.ErrorRate > 0 && p.ErrorRate < 1 {
+		e = float64(p.ErrorRate)
+	}
+	v := float64(card)
+	return Estimate{Value: v, Low: v * (1 - e), High: v * (1 + e)}
+}
+
+func newOverlap(a, b, union Estimate) *Overlap {
+	o := &Overlap{A: a, B: b}
+
+	// The union is at least the larger population and at most their sum. Its bounds are
+	// clamped in the same way, then widened to contain the clamped value.
+	lo, hi := math.Max(a.Low, b.Low), a.High+b.High
+	o.Union = Estimate{
+		Value: clamp(union.Value, math.Max(a.Value, b.Value), a.Value+b.Value),
+		Low:   clamp(union.Low, lo, hi),
+		High:  clamp(union.High, lo, hi),
+	}.contain()
+
+	o.Intersection = Estimate{
+		Value: clamp(a.Value+b.Value-o.Union.Value, 0, math.Min(a.Value, b.Value)),
+		Low:   clamp(a.Low+b.Low-o.Union.High, 0, math.Min(a.Low, b.Low)),
+		High:  clamp(a.High+b.High-o.Union.Low, 0, math.Min(a.High, b.High)),
+	}.contain()
+
+	if o.Union.Value > 0 {
+		o.Jaccard = Estimate{
+			Value: o.Intersection.Value / o.Union.Value,
+			Low:   o.Intersection.Low / o.Union.High,
+			High:  1,
+		}
+		if o.Union.Low > 0 {
+			o.Jaccard.High = math.Min(o.Intersection.High/o.Union.Low, 1)
+		}
+		o.Jaccard = o.Jaccard.contain()
+	}
+	return o
+}
+
+// contain widens the bounds of e to include its value.
+func (e Estimate) contain() Estimate {
+	e.Low = math.Min(e.Low, e.Value)
+	e.High = math.Max(e.High, e.Value)
+	return e
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(v, hi))
+}
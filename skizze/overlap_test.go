@@ -0,0 +1,115 @@
+package skizze_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	. "github.com/skizzehq/goskizze/skizze"
+)
+
+func TestEstimateOverlap(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	l := NewLocal()
+	_, err := l.CreateSketch("web", Cardinality, &Properties{ErrorRate: 0.02})
+	assert.Nil(err)
+	_, err = l.CreateSketch("app", Cardinality, &Properties{ErrorRate: 0.02})
+	assert.Nil(err)
+
+	// 3000 users on the web, 2000 on the app, 1000 of them on both.
+	var web, app []string
+	for i := 0; i < 4000; i++ {
+		user := fmt.Sprintf("user%d", i)
+		if i < 3000 {
+			web = append(web, user)
+		}
+		if i >= 2000 {
+			app = append(app, user)
+		}
+	}
+	assert.Nil(l.AddToSketch("web", Cardinality, web...))
+	assert.Nil(l.AddToSketch("app", Cardinality, app...))
+
+	o, err := EstimateOverlap(ctx, l, "web", "app", func(add func(...string) error) error {
+		if err := add(web...); err != nil {
+			return err
+		}
+		return add(app...)
+	})
+	assert.Nil(err)
+	assert.InEpsilon(3000, o.A.Value, 0.05)
+	assert.InEpsilon(4000, o.Union.Value, 0.05)
+	assert.InDelta(1000, o.Intersection.Value, 250)
+	assert.InDelta(0.25, o.Jaccard.Value, 0.07)
+	for _, e := range []Estimate{o.A, o.B, o.Union, o.Intersection, o.Jaccard} {
+		assert.True(e.Low <= e.Value && e.Value <= e.High, "%+v", e)
+	}
+	assert.True(o.Intersection.Low <= 1000 && 1000 <= o.Intersection.High, "%+v", o.Intersection)
+	assert.True(o.Jaccard.High <= 1)
+
+	// The temporary union sketch is deleted.
+	sketches, err := l.ListSketches(Cardinality)
+	assert.Nil(err)
+	assert.Equal(2, len(sketches))
+
+	o2, err := EstimateOverlapWithUnion(ctx, l, "web", "web", "web")
+	assert.Nil(err)
+	assert.Equal(o.A.Value, o2.Intersection.Value)
+	assert.Equal(1.0, o2.Jaccard.Value)
+}
+
+func TestEstimateOverlapClampsUnion(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewLocal()
+	var values []string
+	for i := 0; i < 3000; i++ {
+		values = append(values, fmt.Sprintf("user%d", i))
+	}
+	for name, n := range map[string]int{"web": 3000, "app": 2000, "partial": 1000} {
+		_, err := l.CreateSketch(name, Cardinality, &Properties{ErrorRate: 0.02})
+		assert.Nil(err)
+		assert.Nil(l.AddToSketch(name, Cardinality, values[:n]...))
+	}
+
+	// The union estimate is below |A|, so it is raised to |A| along with its bounds.
+	o, err := EstimateOverlapWithUnion(context.Background(), l, "web", "app", "partial")
+	assert.Nil(err)
+	assert.Equal(o.A.Value, o.Union.Value)
+	assert.Equal(o.B.Value, o.Intersection.Value)
+	for _, e := range []Estimate{o.Union, o.Intersection, o.Jaccard} {
+		assert.True(e.Low <= e.Value && e.Value <= e.High, "%+v", e)
+	}
+	assert.True(o.Union.Low >= o.A.Low, "%+v", o.Union)
+	assert.True(o.Jaccard.High <= 1)
+}
+
+func TestEstimateOverlapErrors(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	l := NewLocal()
+	_, err := EstimateOverlap(ctx, l, "web", "app", func(func(...string) error) error { return nil })
+	assert.True(errors.Is(err, ErrNotFound))
+
+	_, err = l.CreateSketch("web", Cardinality, nil)
+	assert.Nil(err)
+	_, err = l.CreateSketch("app", Cardinality, nil)
+	assert.Nil(err)
+	failed := errors.New("log unavailable")
+	_, err = EstimateOverlap(ctx, l, "web", "app", func(func(...string) error) error { return failed })
+	assert.Equal(failed, err)
+
+	sketches, err := l.ListSketches(Cardinality)
+	assert.Nil(err)
+	assert.Equal(2, len(sketches))
+
+	o, err := EstimateOverlapWithUnion(ctx, l, "web", "app", "app")
+	assert.Nil(err)
+	assert.Equal(Estimate{}, o.Jaccard)
+}
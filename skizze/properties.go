@@ -12,14 +12,28 @@ var (
 	defaultRankSize      int64   = 100
 )
 
+// DefaultProperties returns the properties Skizze gives a new sketch of type t when none
+// are specified. Cardinality sketches have no properties.
+func DefaultProperties(t SketchType) Properties {
+	switch t {
+	case Membership:
+		return Properties{MaxUniqueItems: defaultMembUnique, ErrorRate: defaultMembErrorRate}
+	case Frequency:
+		return Properties{MaxUniqueItems: defaultFreqUnique, ErrorRate: defaultFreqErrorRate}
+	case Ranking:
+		return Properties{Size: defaultRankSize}
+	}
+	return Properties{}
+}
+
 // Properties are configuration settings for a Sketch.
 type Properties struct {
-	MaxUniqueItems int64
-	ErrorRate      float32
+	MaxUniqueItems int64   `json:"maxUniqueItems,omitempty" yaml:"maxUniqueItems,omitempty"`
+	ErrorRate      float32 `json:"errorRate,omitempty" yaml:"errorRate,omitempty"`
 
 	// Size is used by Rankings sketches to determine the number of rankings this
 	// Sketch should track e.g. top 10, top 100, top 1000
-	Size int64
+	Size int64 `json:"size,omitempty" yaml:"size,omitempty"`
 }
 
 func newPropertiesFromRaw(r *pb.SketchProperties) *Properties {
@@ -1,5 +1,9 @@
 package skizze
 
+import (
+	"fmt"
+)
+
 // MembershipResult indicates the result of a membership query for a value.
 type MembershipResult struct {
 	Value    string
@@ -17,3 +21,19 @@ type RankingsResult struct {
 	Value string
 	Count int64
 }
+
+// checkResults verifies a reply has one result per queried sketch.
+func checkResults(got, want int) error {
+	if got != want {
+		return fmt.Errorf("%w: expected %d results, got %d", ErrMalformedReply, want, got)
+	}
+	return nil
+}
+
+// checkValues verifies a result has one entry per queried value.
+func checkValues(got, want int) error {
+	if got != want {
+		return fmt.Errorf("%w: expected %d values, got %d", ErrMalformedReply, want, got)
+	}
+	return nil
+}
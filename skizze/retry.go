@@ -0,0 +1,140 @@
+package skizze
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+	defaultRetryMultiplier     = 1.6
+	defaultRetryCodes          = []codes.Code{codes.Unavailable}
+)
+
+// readMethods are the RPCs which do not modify Skizze and are always safe to retry.
+var readMethods = map[string]bool{
+	"List":           true,
+	"ListAll":        true,
+	"ListDomains":    true,
+	"GetDomain":      true,
+	"GetSketch":      true,
+	"GetMembership":  true,
+	"GetFrequency":   true,
+	"GetRankings":    true,
+	"GetCardinality": true,
+	"GetSnapshot":    true,
+}
+
+// RetryPolicy configures how failed requests are retried. Read requests are retried
+// whenever the policy is set; requests which modify Skizze (e.g. Add) are only retried
+// when RetryWrites is true, as they may have been applied before the failure.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts for a request, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. Defaults to 100ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries. Defaults to 5s.
+	MaxBackoff time.Duration
+	// Multiplier is applied to the delay after each retry. Defaults to 1.6.
+	Multiplier float64
+	// Jitter randomizes each delay by up to this fraction, e.g. 0.2 for +/-20%.
+	Jitter float64
+
+	// RetryableCodes are the gRPC status codes which are retried. Defaults to
+	// codes.Unavailable.
+	RetryableCodes []codes.Code
+
+	// RetryWrites enables retries of requests which modify Skizze.
+	RetryWrites bool
+
+	// OnAttempt, if set, is called after every attempt of a request.
+	OnAttempt func(Attempt)
+}
+
+// Attempt describes a single try of a request, as passed to RetryPolicy.OnAttempt.
+type Attempt struct {
+	// Method is the name of the RPC, e.g. "GetMembership".
+	Method string
+	// Number is the attempt number, starting from 1.
+	Number int
+	// Err is the error returned by the attempt, or nil if it succeeded.
+	Err error
+	// Backoff is the delay before the next attempt, or 0 if there will not be one.
+	Backoff time.Duration
+}
+
+func (p *RetryPolicy) retryable(method string, err error) bool {
+	if !readMethods[method] && !p.RetryWrites {
+		return false
+	}
+	rc := p.RetryableCodes
+	if rc == nil {
+		rc = defaultRetryCodes
+	}
+	code := status.Code(err)
+	for _, c := range rc {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the retry following attempt n.
+func (p *RetryPolicy) backoff(n int) time.Duration {
+	initial, max, mult := p.InitialBackoff, p.MaxBackoff, p.Multiplier
+	if initial <= 0 {
+		initial = defaultRetryInitialBackoff
+	}
+	if max <= 0 {
+		max = defaultRetryMaxBackoff
+	}
+	if mult < 1 {
+		mult = defaultRetryMultiplier
+	}
+
+	d := math.Min(float64(initial)*math.Pow(mult, float64(n-1)), float64(max))
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (rand.Float64()*2 - 1)
+	}
+	return time.Duration(d)
+}
+
+// interceptor returns a grpc.UnaryClientInterceptor which applies the policy.
+func (p *RetryPolicy) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		name := method[strings.LastIndex(method, "/")+1:]
+		for n := 1; ; n++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+
+			var wait time.Duration
+			if err != nil && n < p.MaxAttempts && p.retryable(name, err) {
+				wait = p.backoff(n)
+			}
+			if p.OnAttempt != nil {
+				p.OnAttempt(Attempt{Method: name, Number: n, Err: err, Backoff: wait})
+			}
+			if wait == 0 {
+				return err
+			}
+
+			t := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return err
+			case <-t.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package skizze_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/skizzehq/goskizze/protobuf"
+	. "github.com/skizzehq/goskizze/skizze"
+)
+
+func getRetryClient(t *testing.T, policy *RetryPolicy) (*Client, *fakeSkizze) {
+	assert := assert.New(t)
+
+	fs := newFakeSkizze()
+	<-fs.ready
+
+	c, err := Dial(fs.address, Options{Insecure: true, RetryPolicy: policy})
+	assert.Nil(err)
+	assert.NotNil(c)
+
+	return c, fs
+}
+
+func TestRetryRead(t *testing.T) {
+	assert := assert.New(t)
+
+	var attempts []Attempt
+	c, fs := getRetryClient(t, &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		OnAttempt:      func(a Attempt) { attempts = append(attempts, a) },
+	})
+	defer closeAll(c, fs)
+
+	unavailable := status.Error(codes.Unavailable, "try again")
+	fs.failures = []error{unavailable, unavailable}
+	fs.nextReply = &pb.ListDomainsReply{Names: []string{"dom1"}}
+
+	domains, err := c.ListDomains()
+	assert.Nil(err)
+	assert.Equal([]string{"dom1"}, domains)
+	assert.Equal(int32(3), atomic.LoadInt32(&fs.calls))
+
+	assert.Equal(3, len(attempts))
+	for i, a := range attempts {
+		assert.Equal("ListDomains", a.Method)
+		assert.Equal(i+1, a.Number)
+	}
+	assert.NotNil(attempts[0].Err)
+	assert.True(attempts[0].Backoff > 0)
+	assert.Nil(attempts[2].Err)
+	assert.Equal(time.Duration(0), attempts[2].Backoff)
+}
+
+func TestRetryExhausted(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getRetryClient(t, &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+	defer closeAll(c, fs)
+
+	unavailable := status.Error(codes.Unavailable, "try again")
+	fs.failures = []error{unavailable, unavailable, unavailable}
+
+	_, err := c.GetDomain("mydomain")
+	assert.True(errors.Is(err, ErrUnavailable))
+	assert.Equal(int32(2), atomic.LoadInt32(&fs.calls))
+}
+
+func TestRetrySkipsNonRetryableCodes(t *testing.T) {
+	assert := assert.New(t)
+
+	c, fs := getRetryClient(t, &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+	defer closeAll(c, fs)
+
+	fs.failures = []error{status.Error(codes.NotFound, "no such domain")}
+
+	_, err := c.GetDomain("mydomain")
+	assert.True(errors.Is(err, ErrNotFound))
+	assert.Equal(int32(1), atomic.LoadInt32(&fs.calls))
+}
+
+func TestRetryWrites(t *testing.T) {
+	assert := assert.New(t)
+
+	policy := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	c, fs := getRetryClient(t, policy)
+	defer closeAll(c, fs)
+
+	unavailable := status.Error(codes.Unavailable, "try again")
+	fs.nextReply = &pb.AddReply{}
+
+	// Writes are not retried by default.
+	fs.failures = []error{unavailable}
+	err := c.AddToDomain("mydomain", "one")
+	assert.True(errors.Is(err, ErrUnavailable))
+	assert.Equal(int32(1), atomic.LoadInt32(&fs.calls))
+
+	policy.RetryWrites = true
+	fs.failures = []error{unavailable}
+	err = c.AddToDomain("mydomain", "one")
+	assert.Nil(err)
+	assert.Equal(int32(3), atomic.LoadInt32(&fs.calls))
+}
@@ -0,0 +1,369 @@
+package skizze
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"golang.org/x/net/context"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidSchema is returned when a Schema cannot be parsed or is inconsistent, e.g. it
+// declares the same domain twice.
+var ErrInvalidSchema = errors.New("skizze: invalid schema")
+
+// Schema declares the domains and standalone sketches which should exist in Skizze. It can
+// be written in YAML or JSON:
+//
+//     domains:
+//       - name: users
+//         properties:
+//           rankings: {size: 10}
+//     sketches:
+//       - name: logins
+//         type: cardinality
+//
+// Properties left out of a schema take the Skizze defaults.
+type Schema struct {
+	Domains  []DomainSchema `json:"domains,omitempty" yaml:"domains,omitempty"`
+	Sketches []SketchSchema `json:"sketches,omitempty" yaml:"sketches,omitempty"`
+}
+
+// DomainSchema declares a domain.
+type DomainSchema struct {
+	Name       string            `json:"name" yaml:"name"`
+	Properties *DomainProperties `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// SketchSchema declares a sketch which is not part of a domain. Type is required.
+type SketchSchema struct {
+	Name       string      `json:"name" yaml:"name"`
+	Type       *SketchType `json:"type" yaml:"type"`
+	Properties *Properties `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// ParseSchema parses a YAML or JSON schema and validates it. Unknown fields are rejected.
+func ParseSchema(data []byte) (*Schema, error) {
+	var s Schema
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// LoadSchema reads and parses the YAML or JSON schema in the named file.
+func LoadSchema(filename string) (*Schema, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSchema(data)
+}
+
+// Validate checks that every domain and sketch is named, that every sketch has a known
+// type and that nothing is declared twice.
+func (s *Schema) Validate() error {
+	domains := make(map[string]bool)
+	for _, d := range s.Domains {
+		if d.Name == "" {
+			return fmt.Errorf("%w: domain without a name", ErrInvalidSchema)
+		}
+		if domains[d.Name] {
+			return fmt.Errorf("%w: domain %v declared twice", ErrInvalidSchema, d.Name)
+		}
+		domains[d.Name] = true
+	}
+
+	sketches := make(map[sketchKey]bool)
+	for _, sk := range s.Sketches {
+		if sk.Name == "" {
+			return fmt.Errorf("%w: sketch without a name", ErrInvalidSchema)
+		}
+		if sk.Type == nil {
+			return fmt.Errorf("%w: sketch %v without a type", ErrInvalidSchema, sk.Name)
+		}
+		if _, err := getRawSketchForSketchType(*sk.Type); err != nil {
+			return fmt.Errorf("%w: sketch %v: %v", ErrInvalidSchema, sk.Name, err)
+		}
+		if domains[sk.Name] {
+			return fmt.Errorf("%w: sketch %v has the name of a domain", ErrInvalidSchema, sk.Name)
+		}
+		k := sketchKey{sk.Name, *sk.Type}
+		if sketches[k] {
+			return fmt.Errorf("%w: %v sketch %v declared twice", ErrInvalidSchema, *sk.Type, sk.Name)
+		}
+		sketches[k] = true
+	}
+	return nil
+}
+
+type sketchKey struct {
+	name string
+	typ  SketchType
+}
+
+// ChangeAction is what a Change does.
+type ChangeAction int
+
+const (
+	// CreateChange creates a domain or sketch declared in the schema.
+	CreateChange ChangeAction = iota
+	// DeleteChange deletes a domain or sketch which is not declared in the schema.
+	DeleteChange
+	// ConflictChange reports a domain or sketch whose properties differ from the schema.
+	// Skizze cannot alter properties in place, so conflicts are never applied; delete and
+	// recreate the sketch to resolve one.
+	ConflictChange
+)
+
+func (a ChangeAction) String() string {
+	switch a {
+	case CreateChange:
+		return "create"
+	case DeleteChange:
+		return "delete"
+	case ConflictChange:
+		return "conflict"
+	default:
+		return fmt.Sprintf("ChangeAction(%d)", int(a))
+	}
+}
+
+// Change is a difference between a Schema and the domains and sketches in Skizze.
+type Change struct {
+	Action ChangeAction
+	// Domain is true if the change is to a domain rather than a standalone sketch.
+	Domain bool
+	Name   string
+	// Type is the type of the sketch. For domains it is UnknownType, except for conflicts
+	// where it is the type of the domain's sketch whose properties differ.
+	Type SketchType
+	// Detail describes a conflict.
+	Detail string
+
+	domain *DomainSchema
+	sketch *SketchSchema
+}
+
+func (c Change) String() string {
+	var s string
+	if c.Domain {
+		s = fmt.Sprintf("%v domain %v", c.Action, c.Name)
+	} else {
+		s = fmt.Sprintf("%v %v sketch %v", c.Action, c.Type, c.Name)
+	}
+	if c.Detail != "" {
+		s += ": " + c.Detail
+	}
+	return s
+}
+
+// ApplyOptions configures Apply.
+type ApplyOptions struct {
+	// Delete removes domains and standalone sketches which are not declared in the schema.
+	// Without it, only missing domains and sketches are created.
+	Delete bool
+	// DryRun reports the changes Apply would make without making them.
+	DryRun bool
+}
+
+// Diff compares the schema with the domains and sketches in Skizze. Creates are listed in
+// schema order, followed by deletes and conflicts sorted by name.
+func (c *Client) Diff(ctx context.Context, s *Schema) ([]Change, error) {
+	return diffSchema(ctx, "Diff", c, s)
+}
+
+// Apply creates the domains and sketches declared in the schema which do not exist and,
+// with opts.Delete, deletes those which are not declared. It returns the changes made, or
+// the changes which would be made with opts.DryRun. Conflicts are returned but not
+// resolved. If a change fails, Apply stops and returns the changes made so far.
+func (c *Client) Apply(ctx context.Context, s *Schema, opts ApplyOptions) ([]Change, error) {
+	return applySchema(ctx, c, s, opts)
+}
+
+// Diff is like Client.Diff.
+func (l *Local) Diff(ctx context.Context, s *Schema) ([]Change, error) {
+	return diffSchema(ctx, "Diff", l, s)
+}
+
+// Apply is like Client.Apply.
+func (l *Local) Apply(ctx context.Context, s *Schema, opts ApplyOptions) ([]Change, error) {
+	return applySchema(ctx, l, s, opts)
+}
+
+func diffSchema(ctx context.Context, op string, c Sketcher, s *Schema) ([]Change, error) {
+	if err := s.Validate(); err != nil {
+		return nil, newClientError(op, err)
+	}
+
+	domainNames, err := c.ListDomainsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	all, err := c.ListAllContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domains := make(map[string]bool)
+	for _, name := range domainNames {
+		domains[name] = true
+	}
+	sketches := make(map[sketchKey]*Sketch)
+	for _, sk := range all {
+		sketches[sketchKey{sk.Name, sk.Type}] = sk
+	}
+
+	var creates, others []Change
+	declaredDomains := make(map[string]bool)
+	for i := range s.Domains {
+		d := &s.Domains[i]
+		declaredDomains[d.Name] = true
+		if !domains[d.Name] {
+			creates = append(creates, Change{Action: CreateChange, Domain: true, Name: d.Name, Type: UnknownType, domain: d})
+			continue
+		}
+		if d.Properties == nil {
+			continue
+		}
+		declared := map[SketchType]*Properties{
+			Membership: &d.Properties.MembershipProperties,
+			Frequency:  &d.Properties.FrequencyProperties,
+			Ranking:    &d.Properties.RankingsProperties,
+		}
+		for _, t := range []SketchType{Membership, Frequency, Ranking} {
+			if sk, ok := sketches[sketchKey{d.Name, t}]; ok {
+				if detail := diffProperties(declared[t], sk.Properties); detail != "" {
+					others = append(others, Change{Action: ConflictChange, Domain: true, Name: d.Name, Type: t, Detail: detail})
+				}
+			}
+		}
+	}
+
+	declaredSketches := make(map[sketchKey]bool)
+	for i := range s.Sketches {
+		sk := &s.Sketches[i]
+		k := sketchKey{sk.Name, *sk.Type}
+		declaredSketches[k] = true
+		actual, ok := sketches[k]
+		if !ok {
+			creates = append(creates, Change{Action: CreateChange, Name: sk.Name, Type: *sk.Type, sketch: sk})
+			continue
+		}
+		if detail := diffProperties(sk.Properties, actual.Properties); detail != "" {
+			others = append(others, Change{Action: ConflictChange, Name: sk.Name, Type: *sk.Type, Detail: detail})
+		}
+	}
+
+	for name := range domains {
+		if !declaredDomains[name] {
+			others = append(others, Change{Action: DeleteChange, Domain: true, Name: name, Type: UnknownType})
+		}
+	}
+	for k := range sketches {
+		// The sketches of a domain are deleted with it.
+		if !declaredSketches[k] && !domains[k.name] {
+			others = append(others, Change{Action: DeleteChange, Name: k.name, Type: k.typ})
+		}
+	}
+
+	sort.SliceStable(others, func(i, j int) bool {
+		if others[i].Name != others[j].Name {
+			return others[i].Name < others[j].Name
+		}
+		if others[i].Domain != others[j].Domain {
+			return others[i].Domain
+		}
+		return others[i].Type < others[j].Type
+	})
+	return append(creates, others...), nil
+}
+
+// diffProperties describes how the actual properties of a sketch differ from those
+// declared. Fields which are not declared take the Skizze defaults and are not compared.
+func diffProperties(declared, actual *Properties) string {
+	if declared == nil || actual == nil {
+		return ""
+	}
+	if declared.MaxUniqueItems != 0 && declared.MaxUniqueItems != actual.MaxUniqueItems {
+		return fmt.Sprintf("maxUniqueItems is %v, schema declares %v", actual.MaxUniqueItems, declared.MaxUniqueItems)
+	}
+	if declared.ErrorRate != 0 && declared.ErrorRate != actual.ErrorRate {
+		return fmt.Sprintf("errorRate is %v, schema declares %v", actual.ErrorRate, declared.ErrorRate)
+	}
+	if declared.Size != 0 && declared.Size != actual.Size {
+		return fmt.Sprintf("size is %v, schema declares %v", actual.Size, declared.Size)
+	}
+	return ""
+}
+
+func applySchema(ctx context.Context, c Sketcher, s *Schema, opts ApplyOptions) ([]Change, error) {
+	diff, err := diffSchema(ctx, "Apply", c, s)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for _, ch := range diff {
+		if ch.Action == DeleteChange && !opts.Delete {
+			continue
+		}
+		if ch.Action != ConflictChange && !opts.DryRun {
+			if err := applyChange(ctx, c, ch); err != nil {
+				return changes, err
+			}
+		}
+		changes = append(changes, ch)
+	}
+	return changes, nil
+}
+
+func applyChange(ctx context.Context, c Sketcher, ch Change) (err error) {
+	switch {
+	case ch.Action == CreateChange && ch.Domain && ch.domain.Properties != nil:
+		p := ch.domain.Properties
+		_, err = c.CreateDomainWithPropertiesContext(ctx, ch.Name, &DomainProperties{
+			MembershipProperties: withDefaults(Membership, &p.MembershipProperties),
+			FrequencyProperties:  withDefaults(Frequency, &p.FrequencyProperties),
+			RankingsProperties:   withDefaults(Ranking, &p.RankingsProperties),
+		})
+	case ch.Action == CreateChange && ch.Domain:
+		_, err = c.CreateDomainContext(ctx, ch.Name)
+	case ch.Action == CreateChange:
+		var p *Properties
+		if ch.sketch.Properties != nil {
+			merged := withDefaults(ch.Type, ch.sketch.Properties)
+			p = &merged
+		}
+		_, err = c.CreateSketchContext(ctx, ch.Name, ch.Type, p)
+	case ch.Action == DeleteChange && ch.Domain:
+		err = c.DeleteDomainContext(ctx, ch.Name)
+	case ch.Action == DeleteChange:
+		err = c.DeleteSketchContext(ctx, ch.Name, ch.Type)
+	}
+	return err
+}
+
+// withDefaults fills the fields of p left out of a schema with the defaults for t.
+func withDefaults(t SketchType, p *Properties) Properties {
+	ret := DefaultProperties(t)
+	if p.MaxUniqueItems != 0 {
+		ret.MaxUniqueItems = p.MaxUniqueItems
+	}
+	if p.ErrorRate != 0 {
+		ret.ErrorRate = p.ErrorRate
+	}
+	if p.Size != 0 {
+		ret.Size = p.Size
+	}
+	return ret
+}
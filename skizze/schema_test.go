@@ -0,0 +1,155 @@
+package skizze_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	. "github.com/skizzehq/goskizze/skizze"
+)
+
+const testSchema = `
+domains:
+  - name: users
+    properties:
+      rankings: {size: 10}
+  - name: events
+sketches:
+  - name: logins
+    type: cardinality
+  - name: top
+    type: rank
+    properties:
+      size: 5
+`
+
+func TestParseSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := ParseSchema([]byte(testSchema))
+	assert.Nil(err)
+	assert.Equal(2, len(s.Domains))
+	assert.Equal("users", s.Domains[0].Name)
+	assert.Equal(int64(10), s.Domains[0].Properties.RankingsProperties.Size)
+	assert.Nil(s.Domains[1].Properties)
+	assert.Equal(2, len(s.Sketches))
+	assert.Equal(Cardinality, *s.Sketches[0].Type)
+	assert.Equal(Ranking, *s.Sketches[1].Type)
+	assert.Equal(int64(5), s.Sketches[1].Properties.Size)
+
+	// JSON round trips.
+	data, err := json.Marshal(s)
+	assert.Nil(err)
+	parsed, err := ParseSchema(data)
+	assert.Nil(err)
+	assert.Equal(s, parsed)
+
+	for _, bad := range []string{
+		"domains: [{name: a}, {name: a}]",
+		"domains: [{properties: {}}]",
+		"sketches: [{name: a, type: bogus}]",
+		"sketches: [{name: a}]",
+		"sketches: [{name: a, type: memb}, {name: a, type: membership}]",
+		"domains: [{name: a}]\nsketches: [{name: a, type: card}]",
+		"domain: [{name: a}]",
+		"{",
+	} {
+		_, err := ParseSchema([]byte(bad))
+		assert.True(errors.Is(err, ErrInvalidSchema), bad)
+	}
+	// A sketch without a type is not taken for a membership sketch.
+	err = (&Schema{Sketches: []SketchSchema{{Name: "a"}}}).Validate()
+	assert.Equal("skizze: invalid schema: sketch a without a type", err.Error())
+}
+
+func TestApplySchema(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	l := NewLocal()
+	_, err := l.CreateDomain("events")
+	assert.Nil(err)
+	_, err = l.CreateDomain("stale")
+	assert.Nil(err)
+	_, err = l.CreateSketch("old", Membership, nil)
+	assert.Nil(err)
+
+	s, err := ParseSchema([]byte(testSchema))
+	assert.Nil(err)
+
+	changes, err := l.Diff(ctx, s)
+	assert.Nil(err)
+	var diff []string
+	for _, c := range changes {
+		diff = append(diff, c.String())
+	}
+	assert.Equal([]string{
+		"create domain users",
+		"create cardinality sketch logins",
+		"create ranking sketch top",
+		"delete membership sketch old",
+		"delete domain stale",
+	}, diff)
+
+	changes, err = l.Apply(ctx, s, ApplyOptions{Delete: true, DryRun: true})
+	assert.Nil(err)
+	assert.Equal(5, len(changes))
+	domains, _ := l.ListDomains()
+	assert.Equal([]string{"events", "stale"}, domains)
+
+	changes, err = l.Apply(ctx, s, ApplyOptions{})
+	assert.Nil(err)
+	assert.Equal(3, len(changes))
+	domains, _ = l.ListDomains()
+	assert.Equal([]string{"events", "stale", "users"}, domains)
+
+	d, err := l.GetDomain("users")
+	assert.Nil(err)
+	for _, sk := range d.Sketches {
+		switch sk.Type {
+		case Ranking:
+			assert.Equal(int64(10), sk.Properties.Size)
+		case Membership:
+			assert.True(sk.Properties.MaxUniqueItems > 0)
+		}
+	}
+	sk, err := l.GetSketch("top", Ranking)
+	assert.Nil(err)
+	assert.Equal(int64(5), sk.Properties.Size)
+
+	changes, err = l.Apply(ctx, s, ApplyOptions{Delete: true})
+	assert.Nil(err)
+	assert.Equal(2, len(changes))
+	domains, _ = l.ListDomains()
+	assert.Equal([]string{"events", "users"}, domains)
+	_, err = l.GetSketch("old", Membership)
+	assert.True(errors.Is(err, ErrNotFound))
+
+	changes, err = l.Diff(ctx, s)
+	assert.Nil(err)
+	assert.Equal(0, len(changes))
+}
+
+func TestApplySchemaConflict(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	l := NewLocal()
+	_, err := l.CreateSketch("top", Ranking, &Properties{Size: 50})
+	assert.Nil(err)
+
+	rank := Ranking
+	s := &Schema{Sketches: []SketchSchema{{Name: "top", Type: &rank, Properties: &Properties{Size: 5}}}}
+	changes, err := l.Apply(ctx, s, ApplyOptions{Delete: true})
+	assert.Nil(err)
+	assert.Equal(1, len(changes))
+	assert.Equal(ConflictChange, changes[0].Action)
+	assert.Equal("conflict ranking sketch top: size is 50, schema declares 5", changes[0].String())
+
+	sk, err := l.GetSketch("top", Ranking)
+	assert.Nil(err)
+	assert.Equal(int64(50), sk.Properties.Size)
+}
@@ -3,10 +3,12 @@ package skizze_test
 import (
 	"net"
 	"strconv"
+	"sync"
 	"sync/atomic"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	pb "github.com/skizzehq/goskizze/protobuf"
 )
@@ -16,14 +18,24 @@ type fakeSkizze struct {
 	ready   <-chan bool
 	server  *grpc.Server
 
-	lastRequest interface{}
-	nextReply   interface{}
-	nextError   error
+	calls int32
+
+	// mu serializes the handlers, which share the fields below. Tests set and read them
+	// between calls.
+	mu           sync.Mutex
+	lastRequest  interface{}
+	lastMetadata metadata.MD
+	nextReply    interface{}
+	nextError    error
+	requests     []interface{}
+
+	// failures are returned, in order, by the next calls before any handler runs.
+	failures []error
 }
 
 var port int32 = 6100
 
-func newFakeSkizze() *fakeSkizze {
+func newFakeSkizze(opts ...grpc.ServerOption) *fakeSkizze {
 	p := atomic.AddInt32(&port, 1)
 
 	ready := make(chan bool, 1)
@@ -45,7 +57,7 @@ func newFakeSkizze() *fakeSkizze {
 				panic(err)
 			}
 
-			fs.server = grpc.NewServer()
+			fs.server = grpc.NewServer(append(opts, grpc.UnaryInterceptor(fs.intercept))...)
 			pb.RegisterSkizzeServer(fs.server, fs)
 			ready <- true
 			fs.server.Serve(listener)
@@ -56,11 +68,33 @@ func newFakeSkizze() *fakeSkizze {
 	return fs
 }
 
+func (f *fakeSkizze) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	atomic.AddInt32(&f.calls, 1)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	f.lastMetadata, _ = metadata.FromIncomingContext(ctx)
+	if len(f.failures) > 0 {
+		err := f.failures[0]
+		f.failures = f.failures[1:]
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (f *fakeSkizze) allRequests() []interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]interface{}{}, f.requests...)
+}
+
 func (f *fakeSkizze) CreateSnapshot(ctx context.Context, in *pb.CreateSnapshotRequest) (*pb.CreateSnapshotReply, error) {
+	f.lastRequest = in
 	return f.nextReply.(*pb.CreateSnapshotReply), f.nextError
 }
 
 func (f *fakeSkizze) GetSnapshot(ctx context.Context, in *pb.GetSnapshotRequest) (*pb.GetSnapshotReply, error) {
+	f.lastRequest = in
 	return f.nextReply.(*pb.GetSnapshotReply), f.nextError
 }
 
@@ -0,0 +1,653 @@
+package skizze
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// ShardOptions configures a ShardedClient.
+type ShardOptions struct {
+	// Options are used by DialSharded and AddNode to dial each node.
+	Options Options
+
+	// VirtualNodes is the number of points each node has on the hash ring. More points
+	// spread names more evenly. Defaults to 128.
+	VirtualNodes int
+}
+
+// ShardedClient spreads domains and sketches over several Skizze servers. Each name is
+// routed to a node by consistent hashing, so a domain and its sketches, which share its
+// name, live on the same node. Requests for several names are split per node and sent
+// concurrently, and listings are merged from every node.
+//
+// Skizze cannot move sketches between servers, so a name routed to a new node by AddNode
+// must be recreated there; the MigrationPlan it returns lists them. Listings only include
+// the copy of a name on the node it is routed to, so copies left on other nodes are not
+// listed.
+type ShardedClient struct {
+	opts ShardOptions
+
+	mu    sync.RWMutex
+	ring  *hashRing
+	nodes map[string]Sketcher
+	// gen is incremented whenever the nodes change, so that AddNodeSketcher can detect
+	// changes made while it planned without holding mu.
+	gen int
+}
+
+// DialSharded dials each of addresses and returns a ShardedClient spreading names over
+// them. Nodes are identified by their address, so the same addresses must be given, in
+// any order, for names to be routed consistently.
+func DialSharded(addresses []string, opts ShardOptions) (*ShardedClient, error) {
+	nodes := make(map[string]Sketcher, len(addresses))
+	for _, addr := range addresses {
+		c, err := Dial(addr, opts.Options)
+		if err != nil {
+			closeNodes(nodes)
+			return nil, err
+		}
+		nodes[addr] = c
+	}
+	return NewShardedClient(nodes, opts)
+}
+
+// NewShardedClient returns a ShardedClient spreading names over nodes, which are keyed
+// by a name identifying them on the hash ring, usually their address.
+func NewShardedClient(nodes map[string]Sketcher, opts ShardOptions) (*ShardedClient, error) {
+	if len(nodes) == 0 {
+		return nil, newClientError("NewShardedClient", errors.New("no nodes"))
+	}
+	c := &ShardedClient{opts: opts, ring: newHashRing(opts.VirtualNodes), nodes: make(map[string]Sketcher)}
+	for name, s := range nodes {
+		c.ring.add(name)
+		c.nodes[name] = s
+	}
+	return c, nil
+}
+
+// Close closes the connections to every node.
+func (c *ShardedClient) Close() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	closeNodes(c.nodes)
+}
+
+func closeNodes(nodes map[string]Sketcher) {
+	for _, s := range nodes {
+		if cl, ok := s.(interface{ Close() }); ok {
+			cl.Close()
+		}
+	}
+}
+
+// Nodes returns the names of the nodes, sorted.
+func (c *ShardedClient) Nodes() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ret := make([]string, 0, len(c.nodes))
+	for name := range c.nodes {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// NodeFor returns the node the domain or sketch name is routed to.
+func (c *ShardedClient) NodeFor(name string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ring.get(name)
+}
+
+func (c *ShardedClient) node(name string) Sketcher {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.nodes[c.ring.get(name)]
+}
+
+// Move is a domain or sketch routed to a different node by a change of the nodes.
+type Move struct {
+	// Domain is true if Name is a domain, which moves with its sketches. Type is then
+	// unset.
+	Domain bool
+	Name   string
+	Type   SketchType
+	From   string
+	To     string
+}
+
+func (m Move) String() string {
+	if m.Domain {
+		return fmt.Sprintf("move domain %v from %v to %v", m.Name, m.From, m.To)
+	}
+	return fmt.Sprintf("move %v sketch %v from %v to %v", m.Type, m.Name, m.From, m.To)
+}
+
+// MigrationPlan lists the domains and sketches which must be recreated on a new node, in
+// order of name. Once the node is added, reads and writes of these names go to it, so
+// they are not found, nor listed, until they are recreated there. Their copies on the
+// nodes they moved from are left in place and can be deleted from those nodes directly.
+type MigrationPlan struct {
+	Node  string
+	Moves []Move
+}
+
+// PlanAddNode returns the domains and sketches which would be routed to node if it were
+// added, without adding it.
+func (c *ShardedClient) PlanAddNode(ctx context.Context, node string) (*MigrationPlan, error) {
+	c.mu.RLock()
+	_, exists := c.nodes[node]
+	ring := c.ring.clone()
+	nodes := c.nodeList()
+	c.mu.RUnlock()
+
+	if exists {
+		return nil, newClientError("PlanAddNode", fmt.Errorf("%w: node %v", ErrAlreadyExists, node))
+	}
+	ring.add(node)
+	return planMoves(ctx, nodes, ring, node)
+}
+
+// AddNode dials address and adds it as a node. See AddNodeSketcher.
+func (c *ShardedClient) AddNode(ctx context.Context, address string) (*MigrationPlan, error) {
+	s, err := Dial(address, c.opts.Options)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := c.AddNodeSketcher(ctx, address, s)
+	if err != nil {
+		s.Close()
+	}
+	return plan, err
+}
+
+// AddNodeSketcher adds s as the node named node. Names are routed to it as soon as it is
+// added; the returned plan lists the existing domains and sketches now routed to it,
+// which must be recreated and refilled there. Requests are routed as before while the
+// plan is made.
+func (c *ShardedClient) AddNodeSketcher(ctx context.Context, node string, s Sketcher) (*MigrationPlan, error) {
+	for {
+		c.mu.RLock()
+		_, exists := c.nodes[node]
+		gen := c.gen
+		ring := c.ring.clone()
+		nodes := c.nodeList()
+		c.mu.RUnlock()
+
+		if exists {
+			return nil, newClientError("AddNode", fmt.Errorf("%w: node %v", ErrAlreadyExists, node))
+		}
+		ring.add(node)
+		plan, err := planMoves(ctx, nodes, ring, node)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		if c.gen == gen {
+			c.ring = ring
+			c.nodes[node] = s
+			c.gen++
+			c.mu.Unlock()
+			return plan, nil
+		}
+		// The nodes changed while planning, so the plan is out of date.
+		c.mu.Unlock()
+	}
+}
+
+type namedNode struct {
+	name string
+	s    Sketcher
+}
+
+// nodeList returns the nodes sorted by name. The caller must hold c.mu.
+func (c *ShardedClient) nodeList() []namedNode {
+	ret := make([]namedNode, 0, len(c.nodes))
+	for name, s := range c.nodes {
+		ret = append(ret, namedNode{name, s})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].name < ret[j].name })
+	return ret
+}
+
+// planMoves lists the domains and sketches of nodes which ring routes to another node.
+func planMoves(ctx context.Context, nodes []namedNode, ring *hashRing, to string) (*MigrationPlan, error) {
+	plan := &MigrationPlan{Node: to}
+	var mu sync.Mutex
+	err := eachNode(nodes, func(n namedNode) error {
+		domains, err := n.s.ListDomainsContext(ctx)
+		if err != nil {
+			return err
+		}
+		sketches, err := n.s.ListAllContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		var moves []Move
+		isDomain := make(map[string]bool)
+		for _, d := range domains {
+			isDomain[d] = true
+			if owner := ring.get(d); owner != n.name {
+				moves = append(moves, Move{Domain: true, Name: d, From: n.name, To: owner})
+			}
+		}
+		for _, s := range sketches {
+			if isDomain[s.Name] {
+				continue
+			}
+			if owner := ring.get(s.Name); owner != n.name {
+				moves = append(moves, Move{Name: s.Name, Type: s.Type, From: n.name, To: owner})
+			}
+		}
+		mu.Lock()
+		plan.Moves = append(plan.Moves, moves...)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(plan.Moves, func(i, j int) bool {
+		a, b := plan.Moves[i], plan.Moves[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Domain != b.Domain {
+			return a.Domain
+		}
+		return a.Type < b.Type
+	})
+	return plan, nil
+}
+
+// eachNode calls f concurrently for each node, returning the first error.
+func eachNode(nodes []namedNode, f func(namedNode) error) error {
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(i int, n namedNode) {
+			defer wg.Done()
+			errs[i] = f(n)
+		}(i, n)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allNodes returns the nodes and the ring routing names to them.
+func (c *ShardedClient) allNodes() ([]namedNode, *hashRing) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.nodeList(), c.ring
+}
+
+// eachShard splits names by node and calls f concurrently for each node with its names
+// and their indexes in names, returning the first error.
+func (c *ShardedClient) eachShard(names []string, f func(s Sketcher, names []string, idx []int) error) error {
+	type shard struct {
+		names []string
+		idx   []int
+	}
+	c.mu.RLock()
+	shards := make(map[string]*shard)
+	var order []namedNode
+	for i, name := range names {
+		node := c.ring.get(name)
+		sh, ok := shards[node]
+		if !ok {
+			sh = &shard{}
+			shards[node] = sh
+			order = append(order, namedNode{node, c.nodes[node]})
+		}
+		sh.names = append(sh.names, name)
+		sh.idx = append(sh.idx, i)
+	}
+	c.mu.RUnlock()
+
+	return eachNode(order, func(n namedNode) error {
+		sh := shards[n.name]
+		return f(n.s, sh.names, sh.idx)
+	})
+}
+
+// sortSketches orders sketches merged from several nodes by name then type, as Local
+// lists them.
+func sortSketches(sketches []*Sketch) {
+	sort.Slice(sketches, func(i, j int) bool {
+		if sketches[i].Name != sketches[j].Name {
+			return sketches[i].Name < sketches[j].Name
+		}
+		return sketches[i].Type < sketches[j].Type
+	})
+}
+
+// ListAll gets all the available Sketches from every node.
+func (c *ShardedClient) ListAll() ([]*Sketch, error) {
+	return c.ListAllContext(context.Background())
+}
+
+// ListAllContext is like ListAll but uses the supplied context.
+func (c *ShardedClient) ListAllContext(ctx context.Context) ([]*Sketch, error) {
+	return c.listSketches(func(s Sketcher) ([]*Sketch, error) { return s.ListAllContext(ctx) })
+}
+
+// ListSketches gets all the sketches of the specified type from every node.
+func (c *ShardedClient) ListSketches(t SketchType) ([]*Sketch, error) {
+	return c.ListSketchesContext(context.Background(), t)
+}
+
+// ListSketchesContext is like ListSketches but uses the supplied context.
+func (c *ShardedClient) ListSketchesContext(ctx context.Context, t SketchType) ([]*Sketch, error) {
+	return c.listSketches(func(s Sketcher) ([]*Sketch, error) { return s.ListSketchesContext(ctx, t) })
+}
+
+func (c *ShardedClient) listSketches(list func(Sketcher) ([]*Sketch, error)) ([]*Sketch, error) {
+	var mu sync.Mutex
+	var ret []*Sketch
+	nodes, ring := c.allNodes()
+	err := eachNode(nodes, func(n namedNode) error {
+		sketches, err := list(n.s)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		for _, s := range sketches {
+			if ring.get(s.Name) == n.name {
+				ret = append(ret, s)
+			}
+		}
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortSketches(ret)
+	return ret, nil
+}
+
+// ListDomains gets all the available domains from every node, sorted.
+func (c *ShardedClient) ListDomains() ([]string, error) {
+	return c.ListDomainsContext(context.Background())
+}
+
+// ListDomainsContext is like ListDomains but uses the supplied context.
+func (c *ShardedClient) ListDomainsContext(ctx context.Context) ([]string, error) {
+	var mu sync.Mutex
+	var ret []string
+	nodes, ring := c.allNodes()
+	err := eachNode(nodes, func(n namedNode) error {
+		domains, err := n.s.ListDomainsContext(ctx)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		for _, d := range domains {
+			if ring.get(d) == n.name {
+				ret = append(ret, d)
+			}
+		}
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// CreateDomain creates a new domain with default properties per Sketch.
+func (c *ShardedClient) CreateDomain(name string) (*Domain, error) {
+	return c.CreateDomainContext(context.Background(), name)
+}
+
+// CreateDomainContext is like CreateDomain but uses the supplied context.
+func (c *ShardedClient) CreateDomainContext(ctx context.Context, name string) (*Domain, error) {
+	return c.node(name).CreateDomainContext(ctx, name)
+}
+
+// CreateDomainWithProperties creates a new domain with the specified properties per Sketch.
+func (c *ShardedClient) CreateDomainWithProperties(name string, props *DomainProperties) (*Domain, error) {
+	return c.CreateDomainWithPropertiesContext(context.Background(), name, props)
+}
+
+// CreateDomainWithPropertiesContext is like CreateDomainWithProperties but uses the
+// supplied context.
+func (c *ShardedClient) CreateDomainWithPropertiesContext(ctx context.Context, name string, props *DomainProperties) (*Domain, error) {
+	return c.node(name).CreateDomainWithPropertiesContext(ctx, name, props)
+}
+
+// DeleteDomain deletes a domain
+func (c *ShardedClient) DeleteDomain(name string) error {
+	return c.DeleteDomainContext(context.Background(), name)
+}
+
+// DeleteDomainContext is like DeleteDomain but uses the supplied context.
+func (c *ShardedClient) DeleteDomainContext(ctx context.Context, name string) error {
+	return c.node(name).DeleteDomainContext(ctx, name)
+}
+
+// GetDomain gets the details of a domain.
+func (c *ShardedClient) GetDomain(name string) (*Domain, error) {
+	return c.GetDomainContext(context.Background(), name)
+}
+
+// GetDomainContext is like GetDomain but uses the supplied context.
+func (c *ShardedClient) GetDomainContext(ctx context.Context, name string) (*Domain, error) {
+	return c.node(name).GetDomainContext(ctx, name)
+}
+
+// CreateSketch creates a new sketch.
+func (c *ShardedClient) CreateSketch(name string, t SketchType, p *Properties) (*Sketch, error) {
+	return c.CreateSketchContext(context.Background(), name, t, p)
+}
+
+// CreateSketchContext is like CreateSketch but uses the supplied context.
+func (c *ShardedClient) CreateSketchContext(ctx context.Context, name string, t SketchType, p *Properties) (*Sketch, error) {
+	return c.node(name).CreateSketchContext(ctx, name, t, p)
+}
+
+// DeleteSketch deletes a sketch.
+func (c *ShardedClient) DeleteSketch(name string, t SketchType) error {
+	return c.DeleteSketchContext(context.Background(), name, t)
+}
+
+// DeleteSketchContext is like DeleteSketch but uses the supplied context.
+func (c *ShardedClient) DeleteSketchContext(ctx context.Context, name string, t SketchType) error {
+	return c.node(name).DeleteSketchContext(ctx, name, t)
+}
+
+// GetSketch gets the details of a sketch.
+func (c *ShardedClient) GetSketch(name string, t SketchType) (*Sketch, error) {
+	return c.GetSketchContext(context.Background(), name, t)
+}
+
+// GetSketchContext is like GetSketch but uses the supplied context.
+func (c *ShardedClient) GetSketchContext(ctx context.Context, name string, t SketchType) (*Sketch, error) {
+	return c.node(name).GetSketchContext(ctx, name, t)
+}
+
+// AddToSketch will add the supplied values to the sketch's data set.
+func (c *ShardedClient) AddToSketch(name string, t SketchType, values ...string) error {
+	return c.AddToSketchContext(context.Background(), name, t, values...)
+}
+
+// AddToSketchContext is like AddToSketch but uses the supplied context.
+func (c *ShardedClient) AddToSketchContext(ctx context.Context, name string, t SketchType, values ...string) error {
+	return c.node(name).AddToSketchContext(ctx, name, t, values...)
+}
+
+// AddToDomain will add the supplied values to all the sketches in the domain.
+func (c *ShardedClient) AddToDomain(name string, values ...string) error {
+	return c.AddToDomainContext(context.Background(), name, values...)
+}
+
+// AddToDomainContext is like AddToDomain but uses the supplied context.
+func (c *ShardedClient) AddToDomainContext(ctx context.Context, name string, values ...string) error {
+	return c.node(name).AddToDomainContext(ctx, name, values...)
+}
+
+// GetMembership queries the sketch for membership (true/false) for the provided values.
+func (c *ShardedClient) GetMembership(name string, values ...string) ([]*MembershipResult, error) {
+	return c.GetMembershipContext(context.Background(), name, values...)
+}
+
+// GetMembershipContext is like GetMembership but uses the supplied context.
+func (c *ShardedClient) GetMembershipContext(ctx context.Context, name string, values ...string) ([]*MembershipResult, error) {
+	return c.node(name).GetMembershipContext(ctx, name, values...)
+}
+
+// GetMultiMembership queries multiple sketches for membership of the provided values,
+// sending one request per node.
+func (c *ShardedClient) GetMultiMembership(names []string, values ...string) ([][]*MembershipResult, error) {
+	return c.GetMultiMembershipContext(context.Background(), names, values...)
+}
+
+// GetMultiMembershipContext is like GetMultiMembership but uses the supplied context.
+func (c *ShardedClient) GetMultiMembershipContext(ctx context.Context, names []string, values ...string) ([][]*MembershipResult, error) {
+	ret := make([][]*MembershipResult, len(names))
+	err := c.eachShard(names, func(s Sketcher, names []string, idx []int) error {
+		results, err := s.GetMultiMembershipContext(ctx, names, values...)
+		if err != nil {
+			return err
+		}
+		if err := checkResults(len(results), len(names)); err != nil {
+			return newClientError("GetMultiMembership", err)
+		}
+		for i, r := range results {
+			ret[idx[i]] = r
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetFrequency queries the sketch for frequency for the provided values.
+func (c *ShardedClient) GetFrequency(name string, values ...string) ([]*FrequencyResult, error) {
+	return c.GetFrequencyContext(context.Background(), name, values...)
+}
+
+// GetFrequencyContext is like GetFrequency but uses the supplied context.
+func (c *ShardedClient) GetFrequencyContext(ctx context.Context, name string, values ...string) ([]*FrequencyResult, error) {
+	return c.node(name).GetFrequencyContext(ctx, name, values...)
+}
+
+// GetMultiFrequency queries multiple sketches for the frequency of the provided values,
+// sending one request per node.
+func (c *ShardedClient) GetMultiFrequency(names []string, values ...string) ([][]*FrequencyResult, error) {
+	return c.GetMultiFrequencyContext(context.Background(), names, values...)
+}
+
+// GetMultiFrequencyContext is like GetMultiFrequency but uses the supplied context.
+func (c *ShardedClient) GetMultiFrequencyContext(ctx context.Context, names []string, values ...string) ([][]*FrequencyResult, error) {
+	ret := make([][]*FrequencyResult, len(names))
+	err := c.eachShard(names, func(s Sketcher, names []string, idx []int) error {
+		results, err := s.GetMultiFrequencyContext(ctx, names, values...)
+		if err != nil {
+			return err
+		}
+		if err := checkResults(len(results), len(names)); err != nil {
+			return newClientError("GetMultiFrequency", err)
+		}
+		for i, r := range results {
+			ret[idx[i]] = r
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetRankings queries the sketch for the top rankings.
+func (c *ShardedClient) GetRankings(name string) ([]*RankingsResult, error) {
+	return c.GetRankingsContext(context.Background(), name)
+}
+
+// GetRankingsContext is like GetRankings but uses the supplied context.
+func (c *ShardedClient) GetRankingsContext(ctx context.Context, name string) ([]*RankingsResult, error) {
+	return c.node(name).GetRankingsContext(ctx, name)
+}
+
+// GetMultiRankings queries multiple sketches for their top rankings, sending one request
+// per node.
+func (c *ShardedClient) GetMultiRankings(names []string) ([][]*RankingsResult, error) {
+	return c.GetMultiRankingsContext(context.Background(), names)
+}
+
+// GetMultiRankingsContext is like GetMultiRankings but uses the supplied context.
+func (c *ShardedClient) GetMultiRankingsContext(ctx context.Context, names []string) ([][]*RankingsResult, error) {
+	ret := make([][]*RankingsResult, len(names))
+	err := c.eachShard(names, func(s Sketcher, names []string, idx []int) error {
+		results, err := s.GetMultiRankingsContext(ctx, names)
+		if err != nil {
+			return err
+		}
+		if err := checkResults(len(results), len(names)); err != nil {
+			return newClientError("GetMultiRankings", err)
+		}
+		for i, r := range results {
+			ret[idx[i]] = r
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetCardinality queries the sketch for the cardinality of items.
+func (c *ShardedClient) GetCardinality(name string) (int64, error) {
+	return c.GetCardinalityContext(context.Background(), name)
+}
+
+// GetCardinalityContext is like GetCardinality but uses the supplied context.
+func (c *ShardedClient) GetCardinalityContext(ctx context.Context, name string) (int64, error) {
+	return c.node(name).GetCardinalityContext(ctx, name)
+}
+
+// GetMultiCardinality queries multiple sketches for their cardinality, sending one
+// request per node.
+func (c *ShardedClient) GetMultiCardinality(names []string) ([]int64, error) {
+	return c.GetMultiCardinalityContext(context.Background(), names)
+}
+
+// GetMultiCardinalityContext is like GetMultiCardinality but uses the supplied context.
+func (c *ShardedClient) GetMultiCardinalityContext(ctx context.Context, names []string) ([]int64, error) {
+	ret := make([]int64, len(names))
+	err := c.eachShard(names, func(s Sketcher, names []string, idx []int) error {
+		results, err := s.GetMultiCardinalityContext(ctx, names)
+		if err != nil {
+			return err
+		}
+		if err := checkResults(len(results), len(names)); err != nil {
+			return newClientError("GetMultiCardinality", err)
+		}
+		for i, r := range results {
+			ret[idx[i]] = r
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
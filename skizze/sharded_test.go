@@ -0,0 +1,217 @@
+package skizze_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	. "github.com/skizzehq/goskizze/skizze"
+)
+
+func newSharded(t *testing.T, nodes ...string) (*ShardedClient, map[string]*Local) {
+	locals := make(map[string]*Local)
+	sketchers := make(map[string]Sketcher)
+	for _, n := range nodes {
+		locals[n] = NewLocal()
+		sketchers[n] = locals[n]
+	}
+	c, err := NewShardedClient(sketchers, ShardOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, locals
+}
+
+func TestShardedClient(t *testing.T) {
+	assert := assert.New(t)
+
+	c, locals := newSharded(t, "a:3596", "b:3596", "c:3596")
+	assert.Equal([]string{"a:3596", "b:3596", "c:3596"}, c.Nodes())
+
+	var names []string
+	for i := 0; i < 30; i++ {
+		name := fmt.Sprintf("users%02d", i)
+		names = append(names, name)
+		_, err := c.CreateDomain(name)
+		assert.Nil(err)
+		assert.Nil(c.AddToDomain(name, fmt.Sprint(i), "alvin"))
+
+		// The domain and its sketches live on the node it is routed to.
+		node := locals[c.NodeFor(name)]
+		_, err = node.GetDomain(name)
+		assert.Nil(err)
+		card, err := node.GetCardinality(name)
+		assert.Nil(err)
+		assert.Equal(int64(2), card)
+	}
+	for n, l := range locals {
+		domains, err := l.ListDomains()
+		assert.Nil(err)
+		assert.NotEmpty(domains, "no domains on %v", n)
+	}
+
+	domains, err := c.ListDomains()
+	assert.Nil(err)
+	assert.Equal(names, domains)
+	sketches, err := c.ListAll()
+	assert.Nil(err)
+	assert.Equal(4*len(names), len(sketches))
+	assert.Equal("users00", sketches[0].Name)
+	sketches, err = c.ListSketches(Ranking)
+	assert.Nil(err)
+	assert.Equal(len(names), len(sketches))
+
+	// Multi queries are reassembled in the order of the names.
+	query := []string{names[7], names[0], names[29], names[7]}
+	cards, err := c.GetMultiCardinality(query)
+	assert.Nil(err)
+	assert.Equal([]int64{2, 2, 2, 2}, cards)
+	freqs, err := c.GetMultiFrequency(query, "7", "alvin")
+	assert.Nil(err)
+	assert.Equal(int64(1), freqs[0][0].Count)
+	assert.Equal(int64(0), freqs[1][0].Count)
+	assert.Equal(int64(1), freqs[3][0].Count)
+	assert.Equal(int64(1), freqs[2][1].Count)
+	membs, err := c.GetMultiMembership(query, "29")
+	assert.Nil(err)
+	assert.Equal([]bool{false, false, true, false},
+		[]bool{membs[0][0].IsMember, membs[1][0].IsMember, membs[2][0].IsMember, membs[3][0].IsMember})
+	ranks, err := c.GetMultiRankings(query)
+	assert.Nil(err)
+	assert.Equal(4, len(ranks))
+	assert.Equal(2, len(ranks[2]))
+
+	_, err = c.GetMultiCardinality([]string{names[0], "missing"})
+	assert.True(errors.Is(err, ErrNotFound))
+}
+
+func TestShardedClientAddNode(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	c, locals := newSharded(t, "a:3596", "b:3596")
+	var names []string
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("users%02d", i)
+		names = append(names, name)
+		_, err := c.CreateDomain(name)
+		assert.Nil(err)
+	}
+	_, err := c.CreateSketch("top", Ranking, nil)
+	assert.Nil(err)
+	before := make(map[string]string)
+	for _, name := range append(names, "top") {
+		before[name] = c.NodeFor(name)
+	}
+
+	planned, err := c.PlanAddNode(ctx, "c:3596")
+	assert.Nil(err)
+	plan, err := c.AddNodeSketcher(ctx, "c:3596", NewLocal())
+	assert.Nil(err)
+	assert.Equal(planned, plan)
+	assert.Equal("c:3596", plan.Node)
+
+	// Only the names routed to the new node move, and only to it.
+	var moved int
+	for _, name := range append(names, "top") {
+		if c.NodeFor(name) != before[name] {
+			moved++
+			assert.Equal("c:3596", c.NodeFor(name))
+		}
+	}
+	assert.Equal(moved, len(plan.Moves))
+	assert.True(moved > 10 && moved < 60, "moved %v of 101 names", moved)
+	for _, m := range plan.Moves {
+		assert.Equal(before[m.Name], m.From)
+		assert.Equal("c:3596", m.To)
+		assert.Equal(m.Name != "top", m.Domain)
+	}
+	last := plan.Moves[len(plan.Moves)-1]
+	assert.Equal(fmt.Sprintf("move domain %v from %v to c:3596", last.Name, last.From), last.String())
+
+	// Moved names are neither found nor listed until they are recreated on the new node.
+	_, err = c.GetDomain(last.Name)
+	assert.True(errors.Is(err, ErrNotFound))
+	domains, err := c.ListDomains()
+	assert.Nil(err)
+	assert.Equal(100-(len(plan.Moves)-1), len(domains))
+	assert.NotContains(domains, last.Name)
+	sketches, err := c.ListSketches(Ranking)
+	assert.Nil(err)
+	assert.Equal(101-len(plan.Moves), len(sketches))
+
+	// Once recreated, they are listed once, although the old copy is still there.
+	_, err = c.CreateDomain(last.Name)
+	assert.Nil(err)
+	_, err = locals[last.From].GetDomain(last.Name)
+	assert.Nil(err)
+	domains, err = c.ListDomains()
+	assert.Nil(err)
+	assert.Equal(100-(len(plan.Moves)-2), len(domains))
+	assert.Contains(domains, last.Name)
+	sketches, err = c.ListAll()
+	assert.Nil(err)
+	var listed int
+	for _, sk := range sketches {
+		if sk.Name == last.Name {
+			listed++
+		}
+	}
+	assert.Equal(4, listed)
+
+	_, err = c.AddNodeSketcher(ctx, "c:3596", NewLocal())
+	assert.True(errors.Is(err, ErrAlreadyExists))
+	_, err = NewShardedClient(nil, ShardOptions{})
+	assert.NotNil(err)
+}
+
+// blockingLocal is a Local whose ListDomains waits until release is closed.
+type blockingLocal struct {
+	*Local
+	listing chan bool
+	release chan bool
+}
+
+func (b *blockingLocal) ListDomainsContext(ctx context.Context) ([]string, error) {
+	b.listing <- true
+	<-b.release
+	return b.Local.ListDomainsContext(ctx)
+}
+
+func TestShardedClientAddNodeDoesNotBlockRouting(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &blockingLocal{Local: NewLocal(), listing: make(chan bool, 1), release: make(chan bool)}
+	c, err := NewShardedClient(map[string]Sketcher{"a:3596": b}, ShardOptions{})
+	assert.Nil(err)
+	_, err = c.CreateDomain("users")
+	assert.Nil(err)
+
+	added := make(chan error)
+	go func() {
+		_, err := c.AddNodeSketcher(context.Background(), "b:3596", NewLocal())
+		added <- err
+	}()
+	<-b.listing
+
+	// Requests are served while the plan is made.
+	routed := make(chan error)
+	go func() {
+		_, err := c.GetMultiCardinality([]string{"users"})
+		routed <- err
+	}()
+	select {
+	case err := <-routed:
+		assert.Nil(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("routing blocked by AddNodeSketcher")
+	}
+
+	close(b.release)
+	assert.Nil(<-added)
+	assert.Equal([]string{"a:3596", "b:3596"}, c.Nodes())
+}
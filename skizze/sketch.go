@@ -1,7 +1,8 @@
 package skizze
 
 import (
-	"log"
+	"fmt"
+	"strings"
 
 	pb "github.com/skizzehq/goskizze/protobuf"
 )
@@ -18,13 +19,80 @@ const (
 	Ranking
 	// Cardinality sketches evaluate how many distinct elements are in the data set.
 	Cardinality
+
+	// UnknownType is reported for sketches whose type this client does not recognize,
+	// such as types added by a newer Skizze server.
+	UnknownType SketchType = -1
 )
 
+var sketchTypeNames = map[SketchType]string{
+	Membership:  "membership",
+	Frequency:   "frequency",
+	Ranking:     "ranking",
+	Cardinality: "cardinality",
+}
+
+// ParseSketchType returns the SketchType named s, e.g. "membership" or its short form
+// "memb", as used by Skizze.
+func ParseSketchType(s string) (SketchType, error) {
+	switch strings.ToLower(s) {
+	case "membership", "memb":
+		return Membership, nil
+	case "frequency", "freq":
+		return Frequency, nil
+	case "ranking", "rankings", "rank":
+		return Ranking, nil
+	case "cardinality", "card":
+		return Cardinality, nil
+	default:
+		return UnknownType, fmt.Errorf("%w %q", ErrInvalidSketchType, s)
+	}
+}
+
+func (t SketchType) String() string {
+	if name, ok := sketchTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("SketchType(%d)", int(t))
+}
+
+// unknownTypeName is the text encoding of UnknownType.
+const unknownTypeName = "unknown"
+
+// MarshalText implements encoding.TextMarshaler, encoding a SketchType by its name, so
+// JSON and YAML hold e.g. "ranking" rather than the number of the type. Types this
+// client does not recognize are encoded as "unknown".
+func (t SketchType) MarshalText() ([]byte, error) {
+	if _, ok := sketchTypeNames[t]; !ok {
+		return []byte(unknownTypeName), nil
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the names understood by
+// ParseSketchType and "unknown", which decodes to UnknownType.
+func (t *SketchType) UnmarshalText(text []byte) error {
+	if string(text) == unknownTypeName {
+		*t = UnknownType
+		return nil
+	}
+	parsed, err := ParseSketchType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
 // Sketch describes the details of a sketch
 type Sketch struct {
 	Name       string
 	Type       SketchType
 	Properties *Properties
+
+	// State is the runtime state of the sketch as reported by Skizze. It is nil when the
+	// server did not include it.
+	State *SketchState
 }
 
 func newSketchFromRaw(s *pb.Sketch) *Sketch {
@@ -35,16 +103,20 @@ func newSketchFromRaw(s *pb.Sketch) *Sketch {
 		Name:       s.GetName(),
 		Type:       getSketchTypeForRawType(s.GetType()),
 		Properties: newPropertiesFromRaw(s.GetProperties()),
+		State:      newStateFromRaw(s.GetState()),
 	}
 }
 
-func getRawSketchFromSketch(s Sketch) *pb.Sketch {
-	t := getRawSketchForSketchType(s.Type)
+func getRawSketchFromSketch(s Sketch) (*pb.Sketch, error) {
+	t, err := getRawSketchForSketchType(s.Type)
+	if err != nil {
+		return nil, err
+	}
 	return &pb.Sketch{
 		Name:       &s.Name,
 		Type:       &t,
 		Properties: newRawPropertiesFromProperties(s.Properties),
-	}
+	}, nil
 }
 
 func getSketchTypeForRawType(t pb.SketchType) SketchType {
@@ -58,23 +130,21 @@ func getSketchTypeForRawType(t pb.SketchType) SketchType {
 	case pb.SketchType_CARD:
 		return Cardinality
 	default:
-		log.Panicf("SketchType %v unknown", t)
-		return 0
+		return UnknownType
 	}
 }
 
-func getRawSketchForSketchType(t SketchType) pb.SketchType {
+func getRawSketchForSketchType(t SketchType) (pb.SketchType, error) {
 	switch t {
 	case Membership:
-		return pb.SketchType_MEMB
+		return pb.SketchType_MEMB, nil
 	case Frequency:
-		return pb.SketchType_FREQ
+		return pb.SketchType_FREQ, nil
 	case Ranking:
-		return pb.SketchType_RANK
+		return pb.SketchType_RANK, nil
 	case Cardinality:
-		return pb.SketchType_CARD
+		return pb.SketchType_CARD, nil
 	default:
-		log.Panicf("SketchType %v unknown", t)
-		return 0
+		return 0, fmt.Errorf("%w %v", ErrInvalidSketchType, int(t))
 	}
 }
@@ -0,0 +1,59 @@
+package skizze
+
+import (
+	"golang.org/x/net/context"
+)
+
+//go:generate mockgen -destination=skizzemock/sketcher.go -package=skizzemock github.com/skizzehq/goskizze/skizze Sketcher
+
+// Sketcher is the set of domain, sketch and query methods shared by Client and Local.
+// Code which depends on Sketcher rather than a concrete type can be given a fake, or a
+// decorator adding caching, metrics or namespacing.
+type Sketcher interface {
+	ListAll() ([]*Sketch, error)
+	ListAllContext(ctx context.Context) ([]*Sketch, error)
+	ListSketches(t SketchType) ([]*Sketch, error)
+	ListSketchesContext(ctx context.Context, t SketchType) ([]*Sketch, error)
+	ListDomains() ([]string, error)
+	ListDomainsContext(ctx context.Context) ([]string, error)
+	CreateDomain(name string) (*Domain, error)
+	CreateDomainContext(ctx context.Context, name string) (*Domain, error)
+	CreateDomainWithProperties(name string, props *DomainProperties) (*Domain, error)
+	CreateDomainWithPropertiesContext(ctx context.Context, name string, props *DomainProperties) (*Domain, error)
+	DeleteDomain(name string) error
+	DeleteDomainContext(ctx context.Context, name string) error
+	GetDomain(name string) (*Domain, error)
+	GetDomainContext(ctx context.Context, name string) (*Domain, error)
+	CreateSketch(name string, t SketchType, p *Properties) (*Sketch, error)
+	CreateSketchContext(ctx context.Context, name string, t SketchType, p *Properties) (*Sketch, error)
+	DeleteSketch(name string, t SketchType) error
+	DeleteSketchContext(ctx context.Context, name string, t SketchType) error
+	GetSketch(name string, t SketchType) (*Sketch, error)
+	GetSketchContext(ctx context.Context, name string, t SketchType) (*Sketch, error)
+	AddToSketch(name string, t SketchType, values ...string) error
+	AddToSketchContext(ctx context.Context, name string, t SketchType, values ...string) error
+	AddToDomain(name string, values ...string) error
+	AddToDomainContext(ctx context.Context, name string, values ...string) error
+	GetMembership(name string, values ...string) ([]*MembershipResult, error)
+	GetMembershipContext(ctx context.Context, name string, values ...string) ([]*MembershipResult, error)
+	GetMultiMembership(names []string, values ...string) ([][]*MembershipResult, error)
+	GetMultiMembershipContext(ctx context.Context, names []string, values ...string) ([][]*MembershipResult, error)
+	GetFrequency(name string, values ...string) ([]*FrequencyResult, error)
+	GetFrequencyContext(ctx context.Context, name string, values ...string) ([]*FrequencyResult, error)
+	GetMultiFrequency(names []string, values ...string) ([][]*FrequencyResult, error)
+	GetMultiFrequencyContext(ctx context.Context, names []string, values ...string) ([][]*FrequencyResult, error)
+	GetRankings(name string) ([]*RankingsResult, error)
+	GetRankingsContext(ctx context.Context, name string) ([]*RankingsResult, error)
+	GetMultiRankings(names []string) ([][]*RankingsResult, error)
+	GetMultiRankingsContext(ctx context.Context, names []string) ([][]*RankingsResult, error)
+	GetCardinality(name string) (int64, error)
+	GetCardinalityContext(ctx context.Context, name string) (int64, error)
+	GetMultiCardinality(names []string) ([]int64, error)
+	GetMultiCardinalityContext(ctx context.Context, names []string) ([]int64, error)
+}
+
+var (
+	_ Sketcher = (*Client)(nil)
+	_ Sketcher = (*Local)(nil)
+	_ Sketcher = (*ShardedClient)(nil)
+)
@@ -0,0 +1,49 @@
+package skizze_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/skizzehq/goskizze/skizze"
+	"github.com/skizzehq/goskizze/skizze/skizzemock"
+)
+
+// namespaced is an example Sketcher decorator which prefixes domain names.
+type namespaced struct {
+	Sketcher
+	prefix string
+}
+
+func (n namespaced) GetCardinality(name string) (int64, error) {
+	return n.Sketcher.GetCardinality(n.prefix + name)
+}
+
+func TestSketcherMock(t *testing.T) {
+	assert := assert.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := skizzemock.NewMockSketcher(ctrl)
+	m.EXPECT().GetCardinality("prod:users").Return(int64(42), nil)
+
+	var s Sketcher = namespaced{Sketcher: m, prefix: "prod:"}
+	card, err := s.GetCardinality("users")
+	assert.Nil(err)
+	assert.Equal(int64(42), card)
+}
+
+func TestSketcherLocal(t *testing.T) {
+	assert := assert.New(t)
+
+	var s Sketcher = NewLocal()
+	_, err := s.CreateDomain("prod:users")
+	assert.Nil(err)
+	assert.Nil(s.AddToDomain("prod:users", "alvin", "simon"))
+
+	card, err := namespaced{Sketcher: s, prefix: "prod:"}.GetCardinality("users")
+	assert.Nil(err)
+	assert.Equal(int64(2), card)
+}
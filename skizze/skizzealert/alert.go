@@ -0,0 +1,411 @@
+// Package skizzealert evaluates threshold rules on Skizze sketches and notifies sinks when
+// they fire and recover.
+//
+// A rule compares the frequency of a value in a Frequency sketch, or the cardinality of a
+// Cardinality sketch, with a threshold, either directly or as the relative change over a
+// window. The cardinality of a domain is that of its Cardinality sketch, which has the
+// name of the domain. For example:
+//
+//     rules := []skizzealert.Rule{{
+//         Name:      "hot-key",
+//         Query:     skizzealert.Query{Type: skizze.Frequency, Sketch: "keys", Value: "X"},
+//         Condition: skizzealert.Above,
+//         Threshold: 1000,
+//     }, {
+//         Name:      "user-surge",
+//         Query:     skizzealert.Query{Type: skizze.Cardinality, Sketch: "users"},
+//         Condition: skizzealert.GrewBy,
+//         Threshold: 0.2,
+//         Window:    5 * time.Minute,
+//     }}
+//     engine, err := skizzealert.NewEngine(client, rules, skizzealert.Options{
+//         Sinks: []skizzealert.Sink{&skizzealert.Webhook{URL: "https://alerts.example.com/hook"}},
+//     })
+//     ...
+//     go engine.Run(ctx)
+//
+// Sinks are notified once when a rule starts firing and once when it resolves, and
+// optionally again every Options.Repeat while it keeps firing. Alerts which no sink
+// accepts are raised again at the next evaluation.
+package skizzealert
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/skizzehq/goskizze/skizze"
+)
+
+var defaultInterval = time.Minute
+
+// ErrInvalidRule is returned by NewEngine for rules which cannot be evaluated.
+var ErrInvalidRule = errors.New("skizzealert: invalid rule")
+
+// Query is the quantity a rule watches.
+type Query struct {
+	// Type is skizze.Frequency or skizze.Cardinality.
+	Type skizze.SketchType
+	// Sketch is the name of the sketch, or of the domain whose sketch is queried.
+	Sketch string
+	// Value is the value whose frequency is queried. It is only used by Frequency queries.
+	Value string
+}
+
+func (q Query) String() string {
+	if q.Type == skizze.Frequency {
+		return fmt.Sprintf("frequency of %q in %v", q.Value, q.Sketch)
+	}
+	return fmt.Sprintf("%v of %v", q.Type, q.Sketch)
+}
+
+// Condition is how a rule compares its query with its threshold.
+type Condition int
+
+const (
+	// Above fires when the value is greater than the threshold.
+	Above Condition = iota
+	// Below fires when the value is less than the threshold.
+	Below
+	// GrewBy fires when the value increased by more than the threshold over the window of
+	// the rule, as a fraction of its value at the start of the window, e.g. 0.2 for 20%.
+	// It does not fire until the window has passed, nor while the value at its start is 0.
+	GrewBy
+	// FellBy fires when the value decreased by more than the threshold over the window of
+	// the rule, as a fraction of its value at the start of the window.
+	FellBy
+)
+
+var conditionNames = map[Condition]string{
+	Above:  "above",
+	Below:  "below",
+	GrewBy: "grew by more than",
+	FellBy: "fell by more than",
+}
+
+func (c Condition) String() string {
+	if name, ok := conditionNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Condition(%d)", int(c))
+}
+
+// Rule is a threshold on a sketch query.
+type Rule struct {
+	// Name identifies the rule in alerts. It must be unique.
+	Name      string
+	Query     Query
+	Condition Condition
+	Threshold float64
+	// Window is the period over which GrewBy and FellBy compare values. It should be a
+	// multiple of Options.Interval.
+	Window time.Duration
+}
+
+func (r Rule) String() string {
+	switch r.Condition {
+	case GrewBy, FellBy:
+		return fmt.Sprintf("%v %v %g%% in %v", r.Query, r.Condition, r.Threshold*100, r.Window)
+	}
+	return fmt.Sprintf("%v %v %g", r.Query, r.Condition, r.Threshold)
+}
+
+func (r Rule) validate() error {
+	switch {
+	case r.Name == "":
+		return fmt.Errorf("%w: rule without a name", ErrInvalidRule)
+	case r.Query.Sketch == "":
+		return fmt.Errorf("%w: rule %v has no sketch", ErrInvalidRule, r.Name)
+	case r.Query.Type != skizze.Frequency && r.Query.Type != skizze.Cardinality:
+		return fmt.Errorf("%w: rule %v queries a %v sketch", ErrInvalidRule, r.Name, r.Query.Type)
+	case r.Condition < Above || r.Condition > FellBy:
+		return fmt.Errorf("%w: rule %v has an unknown condition", ErrInvalidRule, r.Name)
+	case (r.Condition == GrewBy || r.Condition == FellBy) && r.Window <= 0:
+		return fmt.Errorf("%w: rule %v has no window", ErrInvalidRule, r.Name)
+	}
+	return nil
+}
+
+// Options configures an Engine.
+type Options struct {
+	// Interval is the time between evaluations in Run. Defaults to 1m.
+	Interval time.Duration
+
+	// Timeout limits each evaluation, including notifications. Defaults to Interval.
+	Timeout time.Duration
+
+	// Repeat, if positive, is the time after which sinks are notified again of a rule
+	// which is still firing. By default they are notified once.
+	Repeat time.Duration
+
+	// Sinks are notified of alerts, in order.
+	Sinks []Sink
+
+	// OnError, if set, is called with the errors of failed evaluations and notifications.
+	OnError func(error)
+
+	// Now returns the current time. Defaults to time.Now; it may be replaced in tests.
+	Now func() time.Time
+}
+
+type sample struct {
+	at    time.Time
+	value float64
+}
+
+// ruleState is what the engine remembers of a rule between evaluations.
+type ruleState struct {
+	// history holds the samples of GrewBy and FellBy rules covering their window.
+	history  []sample
+	firing   bool
+	since    time.Time
+	notified time.Time
+	// pending is when the rule started firing while that has not been delivered yet.
+	pending time.Time
+}
+
+// Engine evaluates rules periodically, notifying sinks of their changes of state.
+type Engine struct {
+	s     skizze.Sketcher
+	rules []Rule
+	opts  Options
+
+	mu     sync.Mutex
+	states []ruleState
+}
+
+// NewEngine returns an Engine evaluating rules with s.
+func NewEngine(s skizze.Sketcher, rules []Rule, opts Options) (*Engine, error) {
+	names := make(map[string]bool)
+	for _, r := range rules {
+		if err := r.validate(); err != nil {
+			return nil, err
+		}
+		if names[r.Name] {
+			return nil, fmt.Errorf("%w: rule %v declared twice", ErrInvalidRule, r.Name)
+		}
+		names[r.Name] = true
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = defaultInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = opts.Interval
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Engine{
+		s:      s,
+		rules:  append([]Rule(nil), rules...),
+		opts:   opts,
+		states: make([]ruleState, len(rules)),
+	}, nil
+}
+
+// Run evaluates the rules every Options.Interval until ctx is done.
+func (e *Engine) Run(ctx context.Context) {
+	t := time.NewTicker(e.opts.Interval)
+	defer t.Stop()
+	for {
+		e.evaluate(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (e *Engine) evaluate(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, e.opts.Timeout)
+	defer cancel()
+	if err := e.Evaluate(ctx); err != nil && e.opts.OnError != nil {
+		e.opts.OnError(err)
+	}
+}
+
+// Evaluate queries Skizze once, updates the state of every rule and notifies the sinks
+// of the alerts raised. If the queries fail the states are unchanged. Failed
+// notifications are passed to Options.OnError and do not stop the others. A change of
+// state is only recorded once a sink accepts its alert, so if every sink fails the alert
+// is raised again by the next evaluation.
+func (e *Engine) Evaluate(ctx context.Context) error {
+	values, err := e.query(ctx)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.opts.Now()
+	for i, r := range e.rules {
+		if a, ok := e.update(r, &e.states[i], values[i], now); ok && e.notify(ctx, a) {
+			e.states[i].commit(a)
+		}
+	}
+	return nil
+}
+
+// query returns the current value of the query of each rule, in rule order. Frequency
+// and Cardinality sketches are each queried with a single request.
+func (e *Engine) query(ctx context.Context) ([]float64, error) {
+	freqSketches, freqValues := indexer{}, indexer{}
+	cardSketches := indexer{}
+	for _, r := range e.rules {
+		if r.Query.Type == skizze.Frequency {
+			freqSketches.add(r.Query.Sketch)
+			freqValues.add(r.Query.Value)
+		} else {
+			cardSketches.add(r.Query.Sketch)
+		}
+	}
+
+	var freqs [][]*skizze.FrequencyResult
+	if len(freqSketches.names) > 0 {
+		var err error
+		freqs, err = e.s.GetMultiFrequencyContext(ctx, freqSketches.names, freqValues.names...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var cards []int64
+	if len(cardSketches.names) > 0 {
+		var err error
+		cards, err = e.s.GetMultiCardinalityContext(ctx, cardSketches.names)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ret := make([]float64, len(e.rules))
+	for i, r := range e.rules {
+		if r.Query.Type == skizze.Frequency {
+			s, v := freqSketches.index[r.Query.Sketch], freqValues.index[r.Query.Value]
+			if s >= len(freqs) || v >= len(freqs[s]) {
+				return nil, fmt.Errorf("%w: missing frequency of %q in %v", skizze.ErrMalformedReply, r.Query.Value, r.Query.Sketch)
+			}
+			ret[i] = float64(freqs[s][v].Count)
+		} else {
+			s := cardSketches.index[r.Query.Sketch]
+			if s >= len(cards) {
+				return nil, fmt.Errorf("%w: missing cardinality of %v", skizze.ErrMalformedReply, r.Query.Sketch)
+			}
+			ret[i] = float64(cards[s])
+		}
+	}
+	return ret, nil
+}
+
+// indexer collects distinct names in order.
+type indexer struct {
+	names []string
+	index map[string]int
+}
+
+func (x *indexer) add(name string) {
+	if x.index == nil {
+		x.index = make(map[string]int)
+	}
+	if _, ok := x.index[name]; !ok {
+		x.index[name] = len(x.names)
+		x.names = append(x.names, name)
+	}
+}
+
+// update records the value of rule r and returns the alert to send, if any.
+func (e *Engine) update(r Rule, st *ruleState, value float64, now time.Time) (Alert, bool) {
+	measured, ok := value, true
+	switch r.Condition {
+	case GrewBy, FellBy:
+		measured, ok = st.change(value, now, r.Window)
+	}
+
+	var firing bool
+	if ok {
+		switch r.Condition {
+		case Above, GrewBy:
+			firing = measured > r.Threshold
+		case Below:
+			firing = measured < r.Threshold
+		case FellBy:
+			firing = -measured > r.Threshold
+		}
+	}
+
+	a := Alert{Rule: r.Name, Description: r.String(), Value: value, Measured: measured, Threshold: r.Threshold, At: now}
+	switch {
+	case firing && !st.firing:
+		if st.pending.IsZero() {
+			st.pending = now
+		}
+		a.State, a.Since = Firing, st.pending
+		return a, true
+	case firing && e.opts.Repeat > 0 && now.Sub(st.notified) >= e.opts.Repeat:
+		a.State, a.Since = Firing, st.since
+		return a, true
+	case !firing && st.firing:
+		a.State, a.Since = Resolved, st.since
+		return a, true
+	case !firing:
+		st.pending = time.Time{}
+	}
+	return Alert{}, false
+}
+
+// commit records the change of state reported by a delivered alert.
+func (st *ruleState) commit(a Alert) {
+	switch a.State {
+	case Firing:
+		st.firing, st.since, st.notified = true, a.Since, a.At
+		st.pending = time.Time{}
+	case Resolved:
+		st.firing = false
+	}
+}
+
+// change records value and returns its relative change since the start of the window.
+// It returns false until a sample at least window old is available, and while that
+// sample is 0.
+func (st *ruleState) change(value float64, now time.Time, window time.Duration) (float64, bool) {
+	st.history = append(st.history, sample{now, value})
+
+	// Keep the newest sample from before the window as the baseline, and those after it.
+	start := now.Add(-window)
+	base := -1
+	for i, s := range st.history {
+		if s.at.After(start) {
+			break
+		}
+		base = i
+	}
+	if base < 0 {
+		return 0, false
+	}
+	st.history = st.history[base:]
+
+	prev := st.history[0].value
+	if prev == 0 {
+		return 0, false
+	}
+	return (value - prev) / prev, true
+}
+
+// notify sends a to every sink and reports whether any accepted it. With no sinks the
+// alert counts as delivered.
+func (e *Engine) notify(ctx context.Context, a Alert) bool {
+	delivered := len(e.opts.Sinks) == 0
+	for _, s := range e.opts.Sinks {
+		err := s.Notify(ctx, a)
+		if err == nil {
+			delivered = true
+		} else if e.opts.OnError != nil {
+			e.opts.OnError(fmt.Errorf("notifying %v of rule %v: %w", a.State, a.Rule, err))
+		}
+	}
+	return delivered
+}
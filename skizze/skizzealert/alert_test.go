@@ -0,0 +1,194 @@
+package skizzealert_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"github.com/skizzehq/goskizze/skizze"
+	"github.com/skizzehq/goskizze/skizze/skizzealert"
+)
+
+func TestEngine(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	l := skizze.NewLocal()
+	_, err := l.CreateDomain("users")
+	assert.Nil(err)
+
+	now := time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC)
+	var alerts []skizzealert.Alert
+	e, err := skizzealert.NewEngine(l, []skizzealert.Rule{{
+		Name:      "hot-user",
+		Query:     skizzealert.Query{Type: skizze.Frequency, Sketch: "users", Value: "alvin"},
+		Condition: skizzealert.Above,
+		Threshold: 2,
+	}, {
+		Name:      "surge",
+		Query:     skizzealert.Query{Type: skizze.Cardinality, Sketch: "users"},
+		Condition: skizzealert.GrewBy,
+		Threshold: 0.2,
+		Window:    5 * time.Minute,
+	}}, skizzealert.Options{
+		Sinks: []skizzealert.Sink{skizzealert.SinkFunc(func(ctx context.Context, a skizzealert.Alert) error {
+			alerts = append(alerts, a)
+			return nil
+		})},
+		Now: func() time.Time { return now },
+	})
+	assert.Nil(err)
+
+	assert.Nil(l.AddToDomain("users", "alvin", "simon", "theodore", "claire", "alvin"))
+	assert.Nil(e.Evaluate(ctx))
+	assert.Empty(alerts)
+
+	// Firing alerts are not repeated.
+	now = now.Add(5 * time.Minute)
+	assert.Nil(l.AddToDomain("users", "alvin", "gary"))
+	assert.Nil(e.Evaluate(ctx))
+	assert.Nil(e.Evaluate(ctx))
+	assert.Equal(2, len(alerts))
+	assert.Equal("hot-user", alerts[0].Rule)
+	assert.Equal(skizzealert.Firing, alerts[0].State)
+	assert.Equal(float64(3), alerts[0].Value)
+	assert.Equal(`frequency of "alvin" in users above 2`, alerts[0].Description)
+	assert.Equal("surge", alerts[1].Rule)
+	assert.Equal(float64(5), alerts[1].Value)
+	assert.InDelta(0.25, alerts[1].Measured, 1e-9)
+	assert.Equal(now, alerts[1].Since)
+
+	// The growth over the last 5 minutes is 0.
+	now = now.Add(5 * time.Minute)
+	assert.Nil(e.Evaluate(ctx))
+	assert.Equal(3, len(alerts))
+	assert.Equal("surge", alerts[2].Rule)
+	assert.Equal(skizzealert.Resolved, alerts[2].State)
+	assert.Equal(now.Add(-5*time.Minute), alerts[2].Since)
+}
+
+func TestEngineErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := skizzealert.NewEngine(skizze.NewLocal(), []skizzealert.Rule{{
+		Name:      "surge",
+		Query:     skizzealert.Query{Type: skizze.Cardinality, Sketch: "users"},
+		Condition: skizzealert.GrewBy,
+	}}, skizzealert.Options{})
+	assert.True(errors.Is(err, skizzealert.ErrInvalidRule))
+	_, err = skizzealert.NewEngine(skizze.NewLocal(), []skizzealert.Rule{{
+		Name:  "top",
+		Query: skizzealert.Query{Type: skizze.Ranking, Sketch: "users"},
+	}}, skizzealert.Options{})
+	assert.True(errors.Is(err, skizzealert.ErrInvalidRule))
+
+	// Failed queries leave the rules unchanged.
+	l := skizze.NewLocal()
+	var errs []error
+	e, err := skizzealert.NewEngine(l, []skizzealert.Rule{{
+		Name:      "empty",
+		Query:     skizzealert.Query{Type: skizze.Cardinality, Sketch: "users"},
+		Condition: skizzealert.Below,
+		Threshold: 1,
+	}}, skizzealert.Options{
+		Repeat: time.Nanosecond,
+		Sinks: []skizzealert.Sink{skizzealert.SinkFunc(func(ctx context.Context, a skizzealert.Alert) error {
+			return fmt.Errorf("sink down")
+		})},
+		OnError: func(err error) { errs = append(errs, err) },
+	})
+	assert.Nil(err)
+	assert.True(errors.Is(e.Evaluate(context.Background()), skizze.ErrNotFound))
+
+	_, err = l.CreateSketch("users", skizze.Cardinality, nil)
+	assert.Nil(err)
+	assert.Nil(e.Evaluate(context.Background()))
+	assert.Nil(e.Evaluate(context.Background()))
+	assert.Equal(2, len(errs))
+	assert.Equal("notifying firing of rule empty: sink down", errs[0].Error())
+}
+
+func TestEngineRedelivers(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	l := skizze.NewLocal()
+	_, err := l.CreateSketch("users", skizze.Cardinality, nil)
+	assert.Nil(err)
+
+	now := time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC)
+	down := true
+	var delivered []skizzealert.Alert
+	e, err := skizzealert.NewEngine(l, []skizzealert.Rule{{
+		Name:      "empty",
+		Query:     skizzealert.Query{Type: skizze.Cardinality, Sketch: "users"},
+		Condition: skizzealert.Below,
+		Threshold: 1,
+	}}, skizzealert.Options{
+		Sinks: []skizzealert.Sink{skizzealert.SinkFunc(func(ctx context.Context, a skizzealert.Alert) error {
+			if down {
+				return fmt.Errorf("webhook down")
+			}
+			delivered = append(delivered, a)
+			return nil
+		})},
+		Now: func() time.Time { return now },
+	})
+	assert.Nil(err)
+
+	// The firing alert is raised again until a sink accepts it, keeping its start.
+	start := now
+	assert.Nil(e.Evaluate(ctx))
+	now = now.Add(time.Minute)
+	assert.Nil(e.Evaluate(ctx))
+	assert.Empty(delivered)
+	down = false
+	now = now.Add(time.Minute)
+	assert.Nil(e.Evaluate(ctx))
+	assert.Nil(e.Evaluate(ctx))
+	assert.Equal(1, len(delivered))
+	assert.Equal(skizzealert.Firing, delivered[0].State)
+	assert.Equal(start, delivered[0].Since)
+
+	// So is the resolved alert.
+	assert.Nil(l.AddToSketch("users", skizze.Cardinality, "alvin"))
+	down = true
+	assert.Nil(e.Evaluate(ctx))
+	down = false
+	assert.Nil(e.Evaluate(ctx))
+	assert.Nil(e.Evaluate(ctx))
+	assert.Equal(2, len(delivered))
+	assert.Equal(skizzealert.Resolved, delivered[1].State)
+	assert.Equal(start, delivered[1].Since)
+}
+
+func TestWebhook(t *testing.T) {
+	assert := assert.New(t)
+
+	var got map[string]interface{}
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("application/json", r.Header.Get("Content-Type"))
+		assert.Equal("Bearer secret", r.Header.Get("Authorization"))
+		assert.Nil(json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	w := &skizzealert.Webhook{URL: srv.URL, Header: http.Header{"Authorization": {"Bearer secret"}}}
+	a := skizzealert.Alert{Rule: "surge", State: skizzealert.Resolved, Value: 5, Threshold: 0.2}
+	assert.Nil(w.Notify(context.Background(), a))
+	assert.Equal("surge", got["rule"])
+	assert.Equal("resolved", got["state"])
+	assert.Equal(float64(5), got["value"])
+
+	status = http.StatusBadGateway
+	assert.NotNil(w.Notify(context.Background(), a))
+}
@@ -0,0 +1,110 @@
+package skizzealert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// State is the state of a rule reported by an Alert.
+type State int
+
+const (
+	// Firing is reported when a rule starts firing, and on repeats while it is.
+	Firing State = iota
+	// Resolved is reported when a firing rule stops firing.
+	Resolved
+)
+
+func (s State) String() string {
+	switch s {
+	case Firing:
+		return "firing"
+	case Resolved:
+		return "resolved"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding a State by its name.
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Alert is a notification of the state of a rule.
+type Alert struct {
+	Rule string `json:"rule"`
+	// Description describes the rule, e.g. `frequency of "X" in keys above 1000`.
+	Description string `json:"description"`
+	State       State  `json:"state"`
+	// Value is the value of the query of the rule.
+	Value float64 `json:"value"`
+	// Measured is the quantity compared with the threshold: Value, or its relative change
+	// for GrewBy and FellBy rules.
+	Measured  float64 `json:"measured"`
+	Threshold float64 `json:"threshold"`
+	// Since is when the rule started firing.
+	Since time.Time `json:"since"`
+	// At is when the alert was raised.
+	At time.Time `json:"at"`
+}
+
+// Sink receives alerts.
+type Sink interface {
+	Notify(ctx context.Context, a Alert) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(ctx context.Context, a Alert) error
+
+// Notify calls f.
+func (f SinkFunc) Notify(ctx context.Context, a Alert) error {
+	return f(ctx, a)
+}
+
+// Webhook is a Sink which POSTs each alert as JSON to a URL.
+type Webhook struct {
+	URL string
+	// Header is added to each request, e.g. for authorization.
+	Header http.Header
+	// Client sends the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Notify POSTs a to the webhook. Responses other than 2xx are errors.
+func (w *Webhook) Notify(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range w.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook %v returned %v", w.URL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,510 @@
+// Package skizzehttp exposes Skizze over HTTP with JSON bodies, for services which
+// cannot speak gRPC.
+//
+// The handler serves the following endpoints:
+//
+//     GET    /domains                                 list domain names
+//     POST   /domains                                 create a domain: {"name": "users", "properties": {...}}
+//     GET    /domains/{name}                          get a domain
+//     DELETE /domains/{name}                          delete a domain
+//     POST   /domains/{name}/values                   add values: {"values": ["a", "b"]}
+//     GET    /domains/{name}/membership?v=a&v=b       query the sketches of a domain
+//     GET    /domains/{name}/frequency?v=a&v=b
+//     GET    /domains/{name}/rankings
+//     GET    /domains/{name}/cardinality
+//     GET    /sketches[?type={type}]                  list sketches
+//     POST   /sketches                                create a sketch: {"name": "top", "type": "ranking", "properties": {...}}
+//     GET    /sketches/{type}/{name}                  get a sketch
+//     DELETE /sketches/{type}/{name}                  delete a sketch
+//     POST   /sketches/{type}/{name}/values           add values to a sketch
+//     GET    /sketches/{type}/{name}/{query}          query a sketch, where query is the sketch type as above
+//     GET    /snapshot                                get the status of the last snapshot
+//     POST   /snapshot                                take a snapshot
+//
+// Sketch types are named as accepted by skizze.ParseSketchType. Properties are passed to
+// Skizze as given, so a domain created with properties should set those of each of its
+// sketches. Errors are returned as {"error": "..."} with a status code derived from the
+// skizze error, e.g. 404 for skizze.ErrNotFound.
+package skizzehttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/skizzehq/goskizze/skizze"
+)
+
+// maxBodyBytes limits the size of request bodies.
+const maxBodyBytes = 32 << 20
+
+// Backend is the set of methods the handler calls. It is implemented by skizze.Client and
+// skizze.Local.
+type Backend interface {
+	skizze.Sketcher
+	CreateSnapshotContext(ctx context.Context) (*skizze.Snapshot, error)
+	GetSnapshotContext(ctx context.Context) (*skizze.Snapshot, error)
+}
+
+// NewHandler returns an http.Handler serving the Skizze API backed by b. Requests use the
+// context of the HTTP request, so they are canceled when the client goes away.
+func NewHandler(b Backend) http.Handler {
+	return &handler{b: b}
+}
+
+type handler struct {
+	b Backend
+}
+
+// httpError is an error with the HTTP status it is reported with.
+type httpError struct {
+	status int
+	msg    string
+}
+
+func (e *httpError) Error() string {
+	return e.msg
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &httpError{http.StatusBadRequest, fmt.Sprintf(format, args...)}
+}
+
+var errNotFound = &httpError{http.StatusNotFound, "not found"}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var parts []string
+	for _, p := range strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/") {
+		p, err := url.PathUnescape(p)
+		if err != nil {
+			writeError(w, badRequest("invalid path"))
+			return
+		}
+		parts = append(parts, p)
+	}
+
+	route, allow := h.route(r, parts)
+	if route == nil {
+		if allow == "" {
+			writeError(w, errNotFound)
+			return
+		}
+		w.Header().Set("Allow", allow)
+		writeError(w, &httpError{http.StatusMethodNotAllowed, "method not allowed"})
+		return
+	}
+
+	status, v, err := route(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if v == nil {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, status, v)
+}
+
+type routeFunc func(ctx context.Context) (status int, v interface{}, err error)
+
+// route returns the function serving the request for path parts, or nil and the methods
+// allowed if the path exists but not for the method of r.
+func (h *handler) route(r *http.Request, parts []string) (routeFunc, string) {
+	n := len(parts)
+	method := func(routes map[string]routeFunc) (routeFunc, string) {
+		if f, ok := routes[r.Method]; ok {
+			return f, ""
+		}
+		var allow []string
+		for m := range routes {
+			allow = append(allow, m)
+		}
+		return nil, strings.Join(sortMethods(allow), ", ")
+	}
+
+	switch {
+	case n == 1 && parts[0] == "domains":
+		return method(map[string]routeFunc{
+			http.MethodGet:  h.listDomains,
+			http.MethodPost: func(ctx context.Context) (int, interface{}, error) { return h.createDomain(ctx, r) },
+		})
+	case n == 2 && parts[0] == "domains":
+		name := parts[1]
+		return method(map[string]routeFunc{
+			http.MethodGet:    func(ctx context.Context) (int, interface{}, error) { return h.getDomain(ctx, name) },
+			http.MethodDelete: func(ctx context.Context) (int, interface{}, error) { return h.deleteDomain(ctx, name) },
+		})
+	case n == 3 && parts[0] == "domains" && parts[2] == "values":
+		name := parts[1]
+		return method(map[string]routeFunc{
+			http.MethodPost: func(ctx context.Context) (int, interface{}, error) { return h.add(ctx, r, name, nil) },
+		})
+	case n == 3 && parts[0] == "domains":
+		name := parts[1]
+		t, err := skizze.ParseSketchType(parts[2])
+		if err != nil {
+			return nil, ""
+		}
+		return method(map[string]routeFunc{
+			http.MethodGet: func(ctx context.Context) (int, interface{}, error) { return h.query(ctx, r, name, t) },
+		})
+	case n == 1 && parts[0] == "sketches":
+		return method(map[string]routeFunc{
+			http.MethodGet:  func(ctx context.Context) (int, interface{}, error) { return h.listSketches(ctx, r) },
+			http.MethodPost: func(ctx context.Context) (int, interface{}, error) { return h.createSketch(ctx, r) },
+		})
+	case n >= 3 && n <= 4 && parts[0] == "sketches":
+		t, err := skizze.ParseSketchType(parts[1])
+		if err != nil {
+			return nil, ""
+		}
+		name := parts[2]
+		if n == 3 {
+			return method(map[string]routeFunc{
+				http.MethodGet:    func(ctx context.Context) (int, interface{}, error) { return h.getSketch(ctx, name, t) },
+				http.MethodDelete: func(ctx context.Context) (int, interface{}, error) { return h.deleteSketch(ctx, name, t) },
+			})
+		}
+		if parts[3] == "values" {
+			return method(map[string]routeFunc{
+				http.MethodPost: func(ctx context.Context) (int, interface{}, error) { return h.add(ctx, r, name, &t) },
+			})
+		}
+		if q, err := skizze.ParseSketchType(parts[3]); err != nil || q != t {
+			return nil, ""
+		}
+		return method(map[string]routeFunc{
+			http.MethodGet: func(ctx context.Context) (int, interface{}, error) { return h.query(ctx, r, name, t) },
+		})
+	case n == 1 && parts[0] == "snapshot":
+		return method(map[string]routeFunc{
+			http.MethodGet:  h.getSnapshot,
+			http.MethodPost: h.createSnapshot,
+		})
+	}
+	return nil, ""
+}
+
+func sortMethods(methods []string) []string {
+	order := []string{http.MethodGet, http.MethodPost, http.MethodDelete}
+	var ret []string
+	for _, m := range order {
+		for _, a := range methods {
+			if a == m {
+				ret = append(ret, m)
+			}
+		}
+	}
+	return ret
+}
+
+func (h *handler) listDomains(ctx context.Context) (int, interface{}, error) {
+	names, err := h.b.ListDomainsContext(ctx)
+	if names == nil {
+		names = []string{}
+	}
+	return http.StatusOK, names, err
+}
+
+func (h *handler) createDomain(ctx context.Context, r *http.Request) (int, interface{}, error) {
+	var req skizze.DomainSchema
+	if err := readJSON(r, &req); err != nil {
+		return 0, nil, err
+	}
+	if req.Name == "" {
+		return 0, nil, badRequest("missing domain name")
+	}
+
+	var d *skizze.Domain
+	var err error
+	if req.Properties == nil {
+		d, err = h.b.CreateDomainContext(ctx, req.Name)
+	} else {
+		d, err = h.b.CreateDomainWithPropertiesContext(ctx, req.Name, req.Properties)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, newDomainJSON(d), nil
+}
+
+func (h *handler) getDomain(ctx context.Context, name string) (int, interface{}, error) {
+	d, err := h.b.GetDomainContext(ctx, name)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, newDomainJSON(d), nil
+}
+
+func (h *handler) deleteDomain(ctx context.Context, name string) (int, interface{}, error) {
+	return http.StatusNoContent, nil, h.b.DeleteDomainContext(ctx, name)
+}
+
+func (h *handler) listSketches(ctx context.Context, r *http.Request) (int, interface{}, error) {
+	var sketches []*skizze.Sketch
+	var err error
+	if typ := r.URL.Query().Get("type"); typ != "" {
+		t, perr := skizze.ParseSketchType(typ)
+		if perr != nil {
+			return 0, nil, badRequest("%v", perr)
+		}
+		sketches, err = h.b.ListSketchesContext(ctx, t)
+	} else {
+		sketches, err = h.b.ListAllContext(ctx)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, newSketchesJSON(sketches), nil
+}
+
+// createSketchJSON is the body of POST /sketches. Type is a pointer so that a missing type
+// is rejected rather than read as Membership, the zero SketchType.
+type createSketchJSON struct {
+	Name       string             `json:"name"`
+	Type       *skizze.SketchType `json:"type"`
+	Properties *skizze.Properties `json:"properties,omitempty"`
+}
+
+func (h *handler) createSketch(ctx context.Context, r *http.Request) (int, interface{}, error) {
+	var req createSketchJSON
+	if err := readJSON(r, &req); err != nil {
+		return 0, nil, err
+	}
+	if req.Name == "" {
+		return 0, nil, badRequest("missing sketch name")
+	}
+	if req.Type == nil {
+		return 0, nil, badRequest("missing sketch type")
+	}
+	s, err := h.b.CreateSketchContext(ctx, req.Name, *req.Type, req.Properties)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, newSketchJSON(s), nil
+}
+
+func (h *handler) getSketch(ctx context.Context, name string, t skizze.SketchType) (int, interface{}, error) {
+	s, err := h.b.GetSketchContext(ctx, name, t)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, newSketchJSON(s), nil
+}
+
+func (h *handler) deleteSketch(ctx context.Context, name string, t skizze.SketchType) (int, interface{}, error) {
+	return http.StatusNoContent, nil, h.b.DeleteSketchContext(ctx, name, t)
+}
+
+type valuesJSON struct {
+	Values []string `json:"values"`
+}
+
+// add adds the values in the body of r to the domain name, or to the sketch of type t.
+func (h *handler) add(ctx context.Context, r *http.Request, name string, t *skizze.SketchType) (int, interface{}, error) {
+	var req valuesJSON
+	if err := readJSON(r, &req); err != nil {
+		return 0, nil, err
+	}
+	if len(req.Values) == 0 {
+		return 0, nil, badRequest("no values")
+	}
+	if t == nil {
+		return http.StatusNoContent, nil, h.b.AddToDomainContext(ctx, name, req.Values...)
+	}
+	return http.StatusNoContent, nil, h.b.AddToSketchContext(ctx, name, *t, req.Values...)
+}
+
+type membershipJSON struct {
+	Value    string `json:"value"`
+	IsMember bool   `json:"member"`
+}
+
+type countJSON struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type cardinalityJSON struct {
+	Cardinality int64 `json:"cardinality"`
+}
+
+// query runs the query of sketch type t against the sketch name, with the values in the
+// v query parameters.
+func (h *handler) query(ctx context.Context, r *http.Request, name string, t skizze.SketchType) (int, interface{}, error) {
+	values := r.URL.Query()["v"]
+	if (t == skizze.Membership || t == skizze.Frequency) && len(values) == 0 {
+		return 0, nil, badRequest("no values, set them with ?v=")
+	}
+
+	switch t {
+	case skizze.Membership:
+		results, err := h.b.GetMembershipContext(ctx, name, values...)
+		if err != nil {
+			return 0, nil, err
+		}
+		ret := make([]membershipJSON, len(results))
+		for i, m := range results {
+			ret[i] = membershipJSON{m.Value, m.IsMember}
+		}
+		return http.StatusOK, ret, nil
+	case skizze.Frequency:
+		results, err := h.b.GetFrequencyContext(ctx, name, values...)
+		if err != nil {
+			return 0, nil, err
+		}
+		ret := make([]countJSON, len(results))
+		for i, f := range results {
+			ret[i] = countJSON{f.Value, f.Count}
+		}
+		return http.StatusOK, ret, nil
+	case skizze.Ranking:
+		results, err := h.b.GetRankingsContext(ctx, name)
+		if err != nil {
+			return 0, nil, err
+		}
+		ret := make([]countJSON, len(results))
+		for i, rk := range results {
+			ret[i] = countJSON{rk.Value, rk.Count}
+		}
+		return http.StatusOK, ret, nil
+	default:
+		card, err := h.b.GetCardinalityContext(ctx, name)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, cardinalityJSON{card}, nil
+	}
+}
+
+func (h *handler) getSnapshot(ctx context.Context) (int, interface{}, error) {
+	s, err := h.b.GetSnapshotContext(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, newSnapshotJSON(s), nil
+}
+
+func (h *handler) createSnapshot(ctx context.Context) (int, interface{}, error) {
+	s, err := h.b.CreateSnapshotContext(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusAccepted, newSnapshotJSON(s), nil
+}
+
+type sketchJSON struct {
+	Name       string             `json:"name"`
+	Type       skizze.SketchType  `json:"type"`
+	Properties *skizze.Properties `json:"properties,omitempty"`
+	State      *stateJSON         `json:"state,omitempty"`
+}
+
+type stateJSON struct {
+	FillRate     float32    `json:"fillRate"`
+	LastSnapshot *time.Time `json:"lastSnapshot,omitempty"`
+}
+
+type domainJSON struct {
+	Name     string        `json:"name"`
+	Sketches []*sketchJSON `json:"sketches"`
+}
+
+type snapshotJSON struct {
+	Status    string     `json:"status"`
+	Message   string     `json:"message,omitempty"`
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+func newSketchJSON(s *skizze.Sketch) *sketchJSON {
+	ret := &sketchJSON{Name: s.Name, Type: s.Type, Properties: s.Properties}
+	if s.State != nil {
+		ret.State = &stateJSON{FillRate: s.State.FillRate}
+		if !s.State.LastSnapshot.IsZero() {
+			ret.State.LastSnapshot = &s.State.LastSnapshot
+		}
+	}
+	return ret
+}
+
+func newSketchesJSON(sketches []*skizze.Sketch) []*sketchJSON {
+	ret := make([]*sketchJSON, 0, len(sketches))
+	for _, s := range sketches {
+		// Types unknown to this client cannot be used in a path.
+		if s.Type != skizze.UnknownType {
+			ret = append(ret, newSketchJSON(s))
+		}
+	}
+	return ret
+}
+
+func newDomainJSON(d *skizze.Domain) *domainJSON {
+	return &domainJSON{Name: d.Name, Sketches: newSketchesJSON(d.Sketches)}
+}
+
+var snapshotStates = map[skizze.SnapshotState]string{
+	skizze.Pending:    "pending",
+	skizze.InProgress: "in progress",
+	skizze.Successful: "successful",
+	skizze.Failed:     "failed",
+}
+
+func newSnapshotJSON(s *skizze.Snapshot) *snapshotJSON {
+	ret := &snapshotJSON{Status: snapshotStates[s.Status], Message: s.Message}
+	if ret.Status == "" {
+		ret.Status = "unknown"
+	}
+	if !s.Timestamp.IsZero() {
+		ret.Timestamp = &s.Timestamp
+	}
+	return ret
+}
+
+func readJSON(r *http.Request, v interface{}) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return badRequest("invalid request body: %v", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes err with the HTTP status corresponding to it.
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusOf(err), map[string]string{"error": err.Error()})
+}
+
+func statusOf(err error) int {
+	var herr *httpError
+	switch {
+	case errors.As(err, &herr):
+		return herr.status
+	case errors.Is(err, skizze.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, skizze.ErrAlreadyExists):
+		return http.StatusConflict
+	case errors.Is(err, skizze.ErrInvalidProperties), errors.Is(err, skizze.ErrInvalidSketchType):
+		return http.StatusBadRequest
+	case errors.Is(err, skizze.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, skizze.ErrMalformedReply):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
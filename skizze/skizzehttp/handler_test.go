@@ -0,0 +1,175 @@
+package skizzehttp_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skizzehq/goskizze/skizze"
+	"github.com/skizzehq/goskizze/skizze/skizzehttp"
+)
+
+type gateway struct {
+	t   *testing.T
+	srv *httptest.Server
+}
+
+func newGateway(t *testing.T) *gateway {
+	return &gateway{t: t, srv: httptest.NewServer(skizzehttp.NewHandler(skizze.NewLocal()))}
+}
+
+// do sends a request with an optional JSON body and returns the status and the decoded
+// JSON response.
+func (g *gateway) do(method, path, body string) (int, interface{}) {
+	req, err := http.NewRequest(method, g.srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		g.t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		g.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		g.t.Fatal(err)
+	}
+	var v interface{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &v); err != nil {
+			g.t.Fatalf("%v %v: %v: %s", method, path, err, data)
+		}
+	}
+	return resp.StatusCode, v
+}
+
+func TestDomains(t *testing.T) {
+	assert := assert.New(t)
+	g := newGateway(t)
+	defer g.srv.Close()
+
+	status, v := g.do("POST", "/domains", `{"name": "my users"}`)
+	assert.Equal(http.StatusCreated, status)
+	assert.Equal("my users", v.(map[string]interface{})["name"])
+	assert.Equal(4, len(v.(map[string]interface{})["sketches"].([]interface{})))
+
+	status, _ = g.do("POST", "/domains", `{"name": "my users"}`)
+	assert.Equal(http.StatusConflict, status)
+	status, _ = g.do("POST", "/domains", `{"bogus": true}`)
+	assert.Equal(http.StatusBadRequest, status)
+
+	status, v = g.do("GET", "/domains", "")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal([]interface{}{"my users"}, v)
+
+	status, _ = g.do("POST", "/domains/my%20users/values", `{"values": ["alvin", "simon", "alvin"]}`)
+	assert.Equal(http.StatusNoContent, status)
+
+	status, v = g.do("GET", "/domains/my%20users/membership?v=alvin&v=gary", "")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal([]interface{}{
+		map[string]interface{}{"value": "alvin", "member": true},
+		map[string]interface{}{"value": "gary", "member": false},
+	}, v)
+
+	status, v = g.do("GET", "/domains/my%20users/frequency?v=alvin", "")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal([]interface{}{map[string]interface{}{"value": "alvin", "count": float64(2)}}, v)
+
+	status, v = g.do("GET", "/domains/my%20users/rankings", "")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal(2, len(v.([]interface{})))
+
+	status, v = g.do("GET", "/domains/my%20users/cardinality", "")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal(map[string]interface{}{"cardinality": float64(2)}, v)
+
+	status, _ = g.do("GET", "/domains/my%20users/frequency", "")
+	assert.Equal(http.StatusBadRequest, status)
+
+	status, _ = g.do("DELETE", "/domains/my%20users", "")
+	assert.Equal(http.StatusNoContent, status)
+	status, v = g.do("GET", "/domains/my%20users", "")
+	assert.Equal(http.StatusNotFound, status)
+	assert.NotEmpty(v.(map[string]interface{})["error"])
+}
+
+func TestSketches(t *testing.T) {
+	assert := assert.New(t)
+	g := newGateway(t)
+	defer g.srv.Close()
+
+	status, v := g.do("POST", "/sketches", `{"name": "top", "type": "ranking", "properties": {"size": 2}}`)
+	assert.Equal(http.StatusCreated, status)
+	assert.Equal(map[string]interface{}{"size": float64(2)}, v.(map[string]interface{})["properties"])
+
+	status, _ = g.do("POST", "/sketches", `{"name": "bad", "type": "bogus"}`)
+	assert.Equal(http.StatusBadRequest, status)
+	status, v = g.do("POST", "/sketches", `{"name": "untyped"}`)
+	assert.Equal(http.StatusBadRequest, status)
+	assert.Equal("missing sketch type", v.(map[string]interface{})["error"])
+	status, _ = g.do("GET", "/sketches/membership/untyped", "")
+	assert.Equal(http.StatusNotFound, status)
+
+	status, _ = g.do("POST", "/sketches/rank/top/values", `{"values": ["a", "a", "b", "c"]}`)
+	assert.Equal(http.StatusNoContent, status)
+
+	status, v = g.do("GET", "/sketches/rank/top/rankings", "")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal(map[string]interface{}{"value": "a", "count": float64(2)}, v.([]interface{})[0])
+
+	status, _ = g.do("GET", "/sketches/rank/top/membership?v=a", "")
+	assert.Equal(http.StatusNotFound, status)
+
+	status, v = g.do("GET", "/sketches?type=ranking", "")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal(1, len(v.([]interface{})))
+	assert.Equal("ranking", v.([]interface{})[0].(map[string]interface{})["type"])
+
+	status, v = g.do("GET", "/sketches/ranking/top", "")
+	assert.Equal(http.StatusOK, status)
+	assert.NotNil(v.(map[string]interface{})["state"])
+
+	status, _ = g.do("DELETE", "/sketches/ranking/top", "")
+	assert.Equal(http.StatusNoContent, status)
+	status, _ = g.do("GET", "/sketches/ranking/top", "")
+	assert.Equal(http.StatusNotFound, status)
+}
+
+func TestSnapshot(t *testing.T) {
+	assert := assert.New(t)
+	g := newGateway(t)
+	defer g.srv.Close()
+
+	status, v := g.do("POST", "/snapshot", "")
+	assert.Equal(http.StatusAccepted, status)
+	assert.NotEmpty(v.(map[string]interface{})["status"])
+
+	status, v = g.do("GET", "/snapshot", "")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal("successful", v.(map[string]interface{})["status"])
+}
+
+func TestRoutes(t *testing.T) {
+	assert := assert.New(t)
+	g := newGateway(t)
+	defer g.srv.Close()
+
+	status, _ := g.do("GET", "/bogus", "")
+	assert.Equal(http.StatusNotFound, status)
+	status, _ = g.do("GET", "/sketches/bogus/top", "")
+	assert.Equal(http.StatusNotFound, status)
+
+	req, _ := http.NewRequest("PUT", g.srv.URL+"/domains", nil)
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusMethodNotAllowed, resp.StatusCode)
+	assert.Equal("GET, POST", resp.Header.Get("Allow"))
+}
@@ -0,0 +1,688 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/skizzehq/goskizze/skizze (interfaces: Sketcher)
+
+// Package skizzemock is a generated GoMock package.
+package skizzemock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	skizze "github.com/skizzehq/goskizze/skizze"
+)
+
+// MockSketcher is a mock of Sketcher interface.
+type MockSketcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockSketcherMockRecorder
+}
+
+// MockSketcherMockRecorder is the mock recorder for MockSketcher.
+type MockSketcherMockRecorder struct {
+	mock *MockSketcher
+}
+
+// NewMockSketcher creates a new mock instance.
+func NewMockSketcher(ctrl *gomock.Controller) *MockSketcher {
+	mock := &MockSketcher{ctrl: ctrl}
+	mock.recorder = &MockSketcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSketcher) EXPECT() *MockSketcherMockRecorder {
+	return m.recorder
+}
+
+// AddToDomain mocks base method.
+func (m *MockSketcher) AddToDomain(arg0 string, arg1 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddToDomain", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToDomain indicates an expected call of AddToDomain.
+func (mr *MockSketcherMockRecorder) AddToDomain(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToDomain", reflect.TypeOf((*MockSketcher)(nil).AddToDomain), varargs...)
+}
+
+// AddToDomainContext mocks base method.
+func (m *MockSketcher) AddToDomainContext(arg0 context.Context, arg1 string, arg2 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddToDomainContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToDomainContext indicates an expected call of AddToDomainContext.
+func (mr *MockSketcherMockRecorder) AddToDomainContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToDomainContext", reflect.TypeOf((*MockSketcher)(nil).AddToDomainContext), varargs...)
+}
+
+// AddToSketch mocks base method.
+func (m *MockSketcher) AddToSketch(arg0 string, arg1 skizze.SketchType, arg2 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddToSketch", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToSketch indicates an expected call of AddToSketch.
+func (mr *MockSketcherMockRecorder) AddToSketch(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToSketch", reflect.TypeOf((*MockSketcher)(nil).AddToSketch), varargs...)
+}
+
+// AddToSketchContext mocks base method.
+func (m *MockSketcher) AddToSketchContext(arg0 context.Context, arg1 string, arg2 skizze.SketchType, arg3 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddToSketchContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToSketchContext indicates an expected call of AddToSketchContext.
+func (mr *MockSketcherMockRecorder) AddToSketchContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToSketchContext", reflect.TypeOf((*MockSketcher)(nil).AddToSketchContext), varargs...)
+}
+
+// CreateDomain mocks base method.
+func (m *MockSketcher) CreateDomain(arg0 string) (*skizze.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDomain", arg0)
+	ret0, _ := ret[0].(*skizze.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDomain indicates an expected call of CreateDomain.
+func (mr *MockSketcherMockRecorder) CreateDomain(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDomain", reflect.TypeOf((*MockSketcher)(nil).CreateDomain), arg0)
+}
+
+// CreateDomainContext mocks base method.
+func (m *MockSketcher) CreateDomainContext(arg0 context.Context, arg1 string) (*skizze.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDomainContext", arg0, arg1)
+	ret0, _ := ret[0].(*skizze.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDomainContext indicates an expected call of CreateDomainContext.
+func (mr *MockSketcherMockRecorder) CreateDomainContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDomainContext", reflect.TypeOf((*MockSketcher)(nil).CreateDomainContext), arg0, arg1)
+}
+
+// CreateDomainWithProperties mocks base method.
+func (m *MockSketcher) CreateDomainWithProperties(arg0 string, arg1 *skizze.DomainProperties) (*skizze.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDomainWithProperties", arg0, arg1)
+	ret0, _ := ret[0].(*skizze.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDomainWithProperties indicates an expected call of CreateDomainWithProperties.
+func (mr *MockSketcherMockRecorder) CreateDomainWithProperties(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDomainWithProperties", reflect.TypeOf((*MockSketcher)(nil).CreateDomainWithProperties), arg0, arg1)
+}
+
+// CreateDomainWithPropertiesContext mocks base method.
+func (m *MockSketcher) CreateDomainWithPropertiesContext(arg0 context.Context, arg1 string, arg2 *skizze.DomainProperties) (*skizze.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDomainWithPropertiesContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*skizze.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDomainWithPropertiesContext indicates an expected call of CreateDomainWithPropertiesContext.
+func (mr *MockSketcherMockRecorder) CreateDomainWithPropertiesContext(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDomainWithPropertiesContext", reflect.TypeOf((*MockSketcher)(nil).CreateDomainWithPropertiesContext), arg0, arg1, arg2)
+}
+
+// CreateSketch mocks base method.
+func (m *MockSketcher) CreateSketch(arg0 string, arg1 skizze.SketchType, arg2 *skizze.Properties) (*skizze.Sketch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSketch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*skizze.Sketch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSketch indicates an expected call of CreateSketch.
+func (mr *MockSketcherMockRecorder) CreateSketch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSketch", reflect.TypeOf((*MockSketcher)(nil).CreateSketch), arg0, arg1, arg2)
+}
+
+// CreateSketchContext mocks base method.
+func (m *MockSketcher) CreateSketchContext(arg0 context.Context, arg1 string, arg2 skizze.SketchType, arg3 *skizze.Properties) (*skizze.Sketch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSketchContext", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*skizze.Sketch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSketchContext indicates an expected call of CreateSketchContext.
+func (mr *MockSketcherMockRecorder) CreateSketchContext(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSketchContext", reflect.TypeOf((*MockSketcher)(nil).CreateSketchContext), arg0, arg1, arg2, arg3)
+}
+
+// DeleteDomain mocks base method.
+func (m *MockSketcher) DeleteDomain(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDomain", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDomain indicates an expected call of DeleteDomain.
+func (mr *MockSketcherMockRecorder) DeleteDomain(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDomain", reflect.TypeOf((*MockSketcher)(nil).DeleteDomain), arg0)
+}
+
+// DeleteDomainContext mocks base method.
+func (m *MockSketcher) DeleteDomainContext(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDomainContext", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDomainContext indicates an expected call of DeleteDomainContext.
+func (mr *MockSketcherMockRecorder) DeleteDomainContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDomainContext", reflect.TypeOf((*MockSketcher)(nil).DeleteDomainContext), arg0, arg1)
+}
+
+// DeleteSketch mocks base method.
+func (m *MockSketcher) DeleteSketch(arg0 string, arg1 skizze.SketchType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSketch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSketch indicates an expected call of DeleteSketch.
+func (mr *MockSketcherMockRecorder) DeleteSketch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSketch", reflect.TypeOf((*MockSketcher)(nil).DeleteSketch), arg0, arg1)
+}
+
+// DeleteSketchContext mocks base method.
+func (m *MockSketcher) DeleteSketchContext(arg0 context.Context, arg1 string, arg2 skizze.SketchType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSketchContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSketchContext indicates an expected call of DeleteSketchContext.
+func (mr *MockSketcherMockRecorder) DeleteSketchContext(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSketchContext", reflect.TypeOf((*MockSketcher)(nil).DeleteSketchContext), arg0, arg1, arg2)
+}
+
+// GetCardinality mocks base method.
+func (m *MockSketcher) GetCardinality(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardinality", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardinality indicates an expected call of GetCardinality.
+func (mr *MockSketcherMockRecorder) GetCardinality(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardinality", reflect.TypeOf((*MockSketcher)(nil).GetCardinality), arg0)
+}
+
+// GetCardinalityContext mocks base method.
+func (m *MockSketcher) GetCardinalityContext(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardinalityContext", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardinalityContext indicates an expected call of GetCardinalityContext.
+func (mr *MockSketcherMockRecorder) GetCardinalityContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardinalityContext", reflect.TypeOf((*MockSketcher)(nil).GetCardinalityContext), arg0, arg1)
+}
+
+// GetDomain mocks base method.
+func (m *MockSketcher) GetDomain(arg0 string) (*skizze.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomain", arg0)
+	ret0, _ := ret[0].(*skizze.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomain indicates an expected call of GetDomain.
+func (mr *MockSketcherMockRecorder) GetDomain(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomain", reflect.TypeOf((*MockSketcher)(nil).GetDomain), arg0)
+}
+
+// GetDomainContext mocks base method.
+func (m *MockSketcher) GetDomainContext(arg0 context.Context, arg1 string) (*skizze.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomainContext", arg0, arg1)
+	ret0, _ := ret[0].(*skizze.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomainContext indicates an expected call of GetDomainContext.
+func (mr *MockSketcherMockRecorder) GetDomainContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomainContext", reflect.TypeOf((*MockSketcher)(nil).GetDomainContext), arg0, arg1)
+}
+
+// GetFrequency mocks base method.
+func (m *MockSketcher) GetFrequency(arg0 string, arg1 ...string) ([]*skizze.FrequencyResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFrequency", varargs...)
+	ret0, _ := ret[0].([]*skizze.FrequencyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFrequency indicates an expected call of GetFrequency.
+func (mr *MockSketcherMockRecorder) GetFrequency(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrequency", reflect.TypeOf((*MockSketcher)(nil).GetFrequency), varargs...)
+}
+
+// GetFrequencyContext mocks base method.
+func (m *MockSketcher) GetFrequencyContext(arg0 context.Context, arg1 string, arg2 ...string) ([]*skizze.FrequencyResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFrequencyContext", varargs...)
+	ret0, _ := ret[0].([]*skizze.FrequencyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFrequencyContext indicates an expected call of GetFrequencyContext.
+func (mr *MockSketcherMockRecorder) GetFrequencyContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrequencyContext", reflect.TypeOf((*MockSketcher)(nil).GetFrequencyContext), varargs...)
+}
+
+// GetMembership mocks base method.
+func (m *MockSketcher) GetMembership(arg0 string, arg1 ...string) ([]*skizze.MembershipResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMembership", varargs...)
+	ret0, _ := ret[0].([]*skizze.MembershipResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembership indicates an expected call of GetMembership.
+func (mr *MockSketcherMockRecorder) GetMembership(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembership", reflect.TypeOf((*MockSketcher)(nil).GetMembership), varargs...)
+}
+
+// GetMembershipContext mocks base method.
+func (m *MockSketcher) GetMembershipContext(arg0 context.Context, arg1 string, arg2 ...string) ([]*skizze.MembershipResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMembershipContext", varargs...)
+	ret0, _ := ret[0].([]*skizze.MembershipResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembershipContext indicates an expected call of GetMembershipContext.
+func (mr *MockSketcherMockRecorder) GetMembershipContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipContext", reflect.TypeOf((*MockSketcher)(nil).GetMembershipContext), varargs...)
+}
+
+// GetMultiCardinality mocks base method.
+func (m *MockSketcher) GetMultiCardinality(arg0 []string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultiCardinality", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiCardinality indicates an expected call of GetMultiCardinality.
+func (mr *MockSketcherMockRecorder) GetMultiCardinality(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiCardinality", reflect.TypeOf((*MockSketcher)(nil).GetMultiCardinality), arg0)
+}
+
+// GetMultiCardinalityContext mocks base method.
+func (m *MockSketcher) GetMultiCardinalityContext(arg0 context.Context, arg1 []string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultiCardinalityContext", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiCardinalityContext indicates an expected call of GetMultiCardinalityContext.
+func (mr *MockSketcherMockRecorder) GetMultiCardinalityContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiCardinalityContext", reflect.TypeOf((*MockSketcher)(nil).GetMultiCardinalityContext), arg0, arg1)
+}
+
+// GetMultiFrequency mocks base method.
+func (m *MockSketcher) GetMultiFrequency(arg0 []string, arg1 ...string) ([][]*skizze.FrequencyResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMultiFrequency", varargs...)
+	ret0, _ := ret[0].([][]*skizze.FrequencyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiFrequency indicates an expected call of GetMultiFrequency.
+func (mr *MockSketcherMockRecorder) GetMultiFrequency(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiFrequency", reflect.TypeOf((*MockSketcher)(nil).GetMultiFrequency), varargs...)
+}
+
+// GetMultiFrequencyContext mocks base method.
+func (m *MockSketcher) GetMultiFrequencyContext(arg0 context.Context, arg1 []string, arg2 ...string) ([][]*skizze.FrequencyResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMultiFrequencyContext", varargs...)
+	ret0, _ := ret[0].([][]*skizze.FrequencyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiFrequencyContext indicates an expected call of GetMultiFrequencyContext.
+func (mr *MockSketcherMockRecorder) GetMultiFrequencyContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiFrequencyContext", reflect.TypeOf((*MockSketcher)(nil).GetMultiFrequencyContext), varargs...)
+}
+
+// GetMultiMembership mocks base method.
+func (m *MockSketcher) GetMultiMembership(arg0 []string, arg1 ...string) ([][]*skizze.MembershipResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMultiMembership", varargs...)
+	ret0, _ := ret[0].([][]*skizze.MembershipResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiMembership indicates an expected call of GetMultiMembership.
+func (mr *MockSketcherMockRecorder) GetMultiMembership(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiMembership", reflect.TypeOf((*MockSketcher)(nil).GetMultiMembership), varargs...)
+}
+
+// GetMultiMembershipContext mocks base method.
+func (m *MockSketcher) GetMultiMembershipContext(arg0 context.Context, arg1 []string, arg2 ...string) ([][]*skizze.MembershipResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMultiMembershipContext", varargs...)
+	ret0, _ := ret[0].([][]*skizze.MembershipResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiMembershipContext indicates an expected call of GetMultiMembershipContext.
+func (mr *MockSketcherMockRecorder) GetMultiMembershipContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiMembershipContext", reflect.TypeOf((*MockSketcher)(nil).GetMultiMembershipContext), varargs...)
+}
+
+// GetMultiRankings mocks base method.
+func (m *MockSketcher) GetMultiRankings(arg0 []string) ([][]*skizze.RankingsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultiRankings", arg0)
+	ret0, _ := ret[0].([][]*skizze.RankingsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiRankings indicates an expected call of GetMultiRankings.
+func (mr *MockSketcherMockRecorder) GetMultiRankings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiRankings", reflect.TypeOf((*MockSketcher)(nil).GetMultiRankings), arg0)
+}
+
+// GetMultiRankingsContext mocks base method.
+func (m *MockSketcher) GetMultiRankingsContext(arg0 context.Context, arg1 []string) ([][]*skizze.RankingsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultiRankingsContext", arg0, arg1)
+	ret0, _ := ret[0].([][]*skizze.RankingsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiRankingsContext indicates an expected call of GetMultiRankingsContext.
+func (mr *MockSketcherMockRecorder) GetMultiRankingsContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiRankingsContext", reflect.TypeOf((*MockSketcher)(nil).GetMultiRankingsContext), arg0, arg1)
+}
+
+// GetRankings mocks base method.
+func (m *MockSketcher) GetRankings(arg0 string) ([]*skizze.RankingsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRankings", arg0)
+	ret0, _ := ret[0].([]*skizze.RankingsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRankings indicates an expected call of GetRankings.
+func (mr *MockSketcherMockRecorder) GetRankings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRankings", reflect.TypeOf((*MockSketcher)(nil).GetRankings), arg0)
+}
+
+// GetRankingsContext mocks base method.
+func (m *MockSketcher) GetRankingsContext(arg0 context.Context, arg1 string) ([]*skizze.RankingsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRankingsContext", arg0, arg1)
+	ret0, _ := ret[0].([]*skizze.RankingsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRankingsContext indicates an expected call of GetRankingsContext.
+func (mr *MockSketcherMockRecorder) GetRankingsContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRankingsContext", reflect.TypeOf((*MockSketcher)(nil).GetRankingsContext), arg0, arg1)
+}
+
+// GetSketch mocks base method.
+func (m *MockSketcher) GetSketch(arg0 string, arg1 skizze.SketchType) (*skizze.Sketch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSketch", arg0, arg1)
+	ret0, _ := ret[0].(*skizze.Sketch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSketch indicates an expected call of GetSketch.
+func (mr *MockSketcherMockRecorder) GetSketch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSketch", reflect.TypeOf((*MockSketcher)(nil).GetSketch), arg0, arg1)
+}
+
+// GetSketchContext mocks base method.
+func (m *MockSketcher) GetSketchContext(arg0 context.Context, arg1 string, arg2 skizze.SketchType) (*skizze.Sketch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSketchContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*skizze.Sketch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSketchContext indicates an expected call of GetSketchContext.
+func (mr *MockSketcherMockRecorder) GetSketchContext(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSketchContext", reflect.TypeOf((*MockSketcher)(nil).GetSketchContext), arg0, arg1, arg2)
+}
+
+// ListAll mocks base method.
+func (m *MockSketcher) ListAll() ([]*skizze.Sketch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll")
+	ret0, _ := ret[0].([]*skizze.Sketch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockSketcherMockRecorder) ListAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockSketcher)(nil).ListAll))
+}
+
+// ListAllContext mocks base method.
+func (m *MockSketcher) ListAllContext(arg0 context.Context) ([]*skizze.Sketch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllContext", arg0)
+	ret0, _ := ret[0].([]*skizze.Sketch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllContext indicates an expected call of ListAllContext.
+func (mr *MockSketcherMockRecorder) ListAllContext(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllContext", reflect.TypeOf((*MockSketcher)(nil).ListAllContext), arg0)
+}
+
+// ListDomains mocks base method.
+func (m *MockSketcher) ListDomains() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDomains")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDomains indicates an expected call of ListDomains.
+func (mr *MockSketcherMockRecorder) ListDomains() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDomains", reflect.TypeOf((*MockSketcher)(nil).ListDomains))
+}
+
+// ListDomainsContext mocks base method.
+func (m *MockSketcher) ListDomainsContext(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDomainsContext", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDomainsContext indicates an expected call of ListDomainsContext.
+func (mr *MockSketcherMockRecorder) ListDomainsContext(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDomainsContext", reflect.TypeOf((*MockSketcher)(nil).ListDomainsContext), arg0)
+}
+
+// ListSketches mocks base method.
+func (m *MockSketcher) ListSketches(arg0 skizze.SketchType) ([]*skizze.Sketch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSketches", arg0)
+	ret0, _ := ret[0].([]*skizze.Sketch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSketches indicates an expected call of ListSketches.
+func (mr *MockSketcherMockRecorder) ListSketches(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSketches", reflect.TypeOf((*MockSketcher)(nil).ListSketches), arg0)
+}
+
+// ListSketchesContext mocks base method.
+func (m *MockSketcher) ListSketchesContext(arg0 context.Context, arg1 skizze.SketchType) ([]*skizze.Sketch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSketchesContext", arg0, arg1)
+	ret0, _ := ret[0].([]*skizze.Sketch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSketchesContext indicates an expected call of ListSketchesContext.
+func (mr *MockSketcherMockRecorder) ListSketchesContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSketchesContext", reflect.TypeOf((*MockSketcher)(nil).ListSketchesContext), arg0, arg1)
+}
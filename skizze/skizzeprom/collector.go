@@ -0,0 +1,275 @@
+// Package skizzeprom exports statistics of Skizze sketches as Prometheus metrics.
+//
+// A Collector periodically lists the sketches in Skizze and queries the cardinality and
+// rankings sketches, exposing:
+//
+//     skizze_sketch_cardinality{sketch}             estimated distinct values of a cardinality sketch
+//     skizze_sketch_ranking_count{sketch,rank,value} count of the top ranked values of a ranking sketch
+//     skizze_sketch_fill_rate{sketch,type}          fill rate of a sketch, when reported by Skizze
+//     skizze_refresh_errors_total                   failed refreshes
+//     skizze_last_refresh_timestamp_seconds         time of the last successful refresh
+//
+// Example:
+//
+//     collector := skizzeprom.NewCollector(client, skizzeprom.Options{TopN: 10})
+//     prometheus.MustRegister(collector)
+//     go collector.Run(ctx)
+//
+package skizzeprom
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+
+	"github.com/skizzehq/goskizze/skizze"
+)
+
+var (
+	defaultInterval = time.Minute
+	defaultTopN     = 10
+)
+
+// Options configures a Collector.
+type Options struct {
+	// Namespace prefixes the metric names. Defaults to "skizze".
+	Namespace string
+
+	// Interval is the time between refreshes in Run. Defaults to 1m.
+	Interval time.Duration
+
+	// Timeout limits each refresh. Defaults to Interval.
+	Timeout time.Duration
+
+	// TopN is the number of top ranked values exported per ranking sketch, bounding the
+	// number of value labels. Defaults to 10.
+	TopN int
+
+	// Include, if set, limits the sketches exported to those whose name it matches.
+	Include *regexp.Regexp
+	// Exclude, if set, omits the sketches whose name it matches.
+	Exclude *regexp.Regexp
+
+	// OnError, if set, is called with the error of each failed refresh, and with the
+	// label values which had to be sanitized or skipped.
+	OnError func(error)
+}
+
+// Collector is a prometheus.Collector exporting sketch statistics. The metrics are those
+// of the last successful refresh.
+type Collector struct {
+	s    skizze.Sketcher
+	opts Options
+
+	cardinality *prometheus.Desc
+	rankCount   *prometheus.Desc
+	fillRate    *prometheus.Desc
+	errors      prometheus.Counter
+	lastRefresh prometheus.Gauge
+
+	mu      sync.RWMutex
+	metrics []prometheus.Metric
+}
+
+// NewCollector returns a Collector which queries s. It exports no sketch metrics until
+// Refresh or Run is called.
+func NewCollector(s skizze.Sketcher, opts Options) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = "skizze"
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = opts.Interval
+	}
+	if opts.TopN <= 0 {
+		opts.TopN = defaultTopN
+	}
+
+	ns := opts.Namespace
+	return &Collector{
+		s:    s,
+		opts: opts,
+		cardinality: prometheus.NewDesc(prometheus.BuildFQName(ns, "sketch", "cardinality"),
+			"Estimated number of distinct values in a cardinality sketch.", []string{"sketch"}, nil),
+		rankCount: prometheus.NewDesc(prometheus.BuildFQName(ns, "sketch", "ranking_count"),
+			"Count of a top ranked value in a ranking sketch.", []string{"sketch", "rank", "value"}, nil),
+		fillRate: prometheus.NewDesc(prometheus.BuildFQName(ns, "sketch", "fill_rate"),
+			"How full a sketch is, from 0 to 1.", []string{"sketch", "type"}, nil),
+		errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "refresh_errors_total",
+			Help:      "Number of failed refreshes of the sketch metrics.",
+		}),
+		lastRefresh: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "last_refresh_timestamp_seconds",
+			Help:      "Time of the last successful refresh of the sketch metrics.",
+		}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.cardinality
+	ch <- c.rankCount
+	ch <- c.fillRate
+	c.errors.Describe(ch)
+	c.lastRefresh.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	for _, m := range c.metrics {
+		ch <- m
+	}
+	c.mu.RUnlock()
+	c.errors.Collect(ch)
+	c.lastRefresh.Collect(ch)
+}
+
+// Run refreshes the metrics every Options.Interval until ctx is done.
+func (c *Collector) Run(ctx context.Context) {
+	t := time.NewTicker(c.opts.Interval)
+	defer t.Stop()
+	for {
+		c.refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (c *Collector) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+	if err := c.Refresh(ctx); err != nil {
+		c.report(err)
+	}
+}
+
+// Refresh queries Skizze and replaces the exported metrics. On error the metrics of the
+// previous refresh are kept.
+func (c *Collector) Refresh(ctx context.Context) error {
+	metrics, err := c.query(ctx)
+	if err != nil {
+		c.errors.Inc()
+		return err
+	}
+
+	c.mu.Lock()
+	c.metrics = metrics
+	c.mu.Unlock()
+	c.lastRefresh.SetToCurrentTime()
+	return nil
+}
+
+func (c *Collector) query(ctx context.Context) ([]prometheus.Metric, error) {
+	sketches, err := c.s.ListAllContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics []prometheus.Metric
+	seen := make(map[metricKey]bool)
+	var cards, ranks []string
+	for _, s := range sketches {
+		if !c.included(s.Name) || s.Type == skizze.UnknownType {
+			continue
+		}
+		if s.State != nil {
+			metrics = c.appendMetric(metrics, seen, c.fillRate, float64(s.State.FillRate), s.Name, s.Type.String())
+		}
+		switch s.Type {
+		case skizze.Cardinality:
+			cards = append(cards, s.Name)
+		case skizze.Ranking:
+			ranks = append(ranks, s.Name)
+		}
+	}
+
+	if len(cards) > 0 {
+		results, err := c.s.GetMultiCardinalityContext(ctx, cards)
+		if err != nil {
+			return nil, err
+		}
+		for i, card := range results {
+			metrics = c.appendMetric(metrics, seen, c.cardinality, float64(card), cards[i])
+		}
+	}
+
+	if len(ranks) > 0 {
+		results, err := c.s.GetMultiRankingsContext(ctx, ranks)
+		if err != nil {
+			return nil, err
+		}
+		for i, rankings := range results {
+			for j, r := range rankings {
+				if j == c.opts.TopN {
+					break
+				}
+				metrics = c.appendMetric(metrics, seen, c.rankCount, float64(r.Count), ranks[i], strconv.Itoa(j+1), r.Value)
+			}
+		}
+	}
+	return metrics, nil
+}
+
+// metricKey identifies a metric by its descriptor and label values.
+type metricKey struct {
+	desc   *prometheus.Desc
+	labels string
+}
+
+// appendMetric appends a gauge to metrics. Sketch names and values are arbitrary bytes but
+// label values must be UTF-8, so invalid sequences are replaced with U+FFFD and reported
+// to Options.OnError. As this can give different sketches or values the same labels,
+// which the registry rejects, seen holds the labels of the metrics appended so far and
+// duplicates are skipped and reported. Metrics which still cannot be built are skipped
+// and reported too.
+func (c *Collector) appendMetric(metrics []prometheus.Metric, seen map[metricKey]bool, desc *prometheus.Desc, v float64, labels ...string) []prometheus.Metric {
+	for i, l := range labels {
+		if !utf8.ValidString(l) {
+			labels[i] = strings.ToValidUTF8(l, "\uFFFD")
+			c.report(fmt.Errorf("label value %q is not valid UTF-8, exported as %q", l, labels[i]))
+		}
+	}
+	// Valid UTF-8 never contains 0xff, so it separates the values unambiguously.
+	k := metricKey{desc, strings.Join(labels, "\xff")}
+	if seen[k] {
+		c.report(fmt.Errorf("skipping metric with duplicate label values %q", labels))
+		return metrics
+	}
+	seen[k] = true
+
+	m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, v, labels...)
+	if err != nil {
+		c.report(err)
+		return metrics
+	}
+	return append(metrics, m)
+}
+
+func (c *Collector) report(err error) {
+	if c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
+
+func (c *Collector) included(name string) bool {
+	if c.opts.Include != nil && !c.opts.Include.MatchString(name) {
+		return false
+	}
+	return c.opts.Exclude == nil || !c.opts.Exclude.MatchString(name)
+}
@@ -0,0 +1,127 @@
+package skizzeprom_test
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"github.com/skizzehq/goskizze/skizze"
+	"github.com/skizzehq/goskizze/skizze/skizzemock"
+	"github.com/skizzehq/goskizze/skizze/skizzeprom"
+)
+
+func TestCollector(t *testing.T) {
+	assert := assert.New(t)
+
+	l := skizze.NewLocal()
+	_, err := l.CreateSketch("visitors", skizze.Cardinality, nil)
+	assert.Nil(err)
+	_, err = l.CreateSketch("pages", skizze.Ranking, nil)
+	assert.Nil(err)
+	_, err = l.CreateSketch("internal", skizze.Cardinality, nil)
+	assert.Nil(err)
+	assert.Nil(l.AddToSketch("visitors", skizze.Cardinality, "alvin", "simon", "alvin"))
+	assert.Nil(l.AddToSketch("pages", skizze.Ranking, "/", "/", "/", "/about", "/about", "/contact"))
+
+	c := skizzeprom.NewCollector(l, skizzeprom.Options{
+		TopN:    2,
+		Exclude: regexp.MustCompile("^internal$"),
+	})
+	assert.Nil(c.Refresh(context.Background()))
+
+	expected := `
+# HELP skizze_sketch_cardinality Estimated number of distinct values in a cardinality sketch.
+# TYPE skizze_sketch_cardinality gauge
+skizze_sketch_cardinality{sketch="visitors"} 2
+# HELP skizze_sketch_ranking_count Count of a top ranked value in a ranking sketch.
+# TYPE skizze_sketch_ranking_count gauge
+skizze_sketch_ranking_count{rank="1",sketch="pages",value="/"} 3
+skizze_sketch_ranking_count{rank="2",sketch="pages",value="/about"} 2
+# HELP skizze_refresh_errors_total Number of failed refreshes of the sketch metrics.
+# TYPE skizze_refresh_errors_total counter
+skizze_refresh_errors_total 0
+`
+	assert.Nil(testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"skizze_sketch_cardinality", "skizze_sketch_ranking_count", "skizze_refresh_errors_total"))
+	assert.Equal(2, testutil.CollectAndCount(c, "skizze_sketch_fill_rate"))
+}
+
+func TestCollectorError(t *testing.T) {
+	assert := assert.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s := skizzemock.NewMockSketcher(ctrl)
+
+	s.EXPECT().ListAllContext(gomock.Any()).Return([]*skizze.Sketch{{Name: "visitors", Type: skizze.Cardinality}}, nil)
+	s.EXPECT().GetMultiCardinalityContext(gomock.Any(), []string{"visitors"}).Return([]int64{5}, nil)
+	s.EXPECT().ListAllContext(gomock.Any()).Return(nil, skizze.ErrUnavailable)
+
+	c := skizzeprom.NewCollector(s, skizzeprom.Options{})
+	assert.Nil(c.Refresh(context.Background()))
+	assert.True(errors.Is(c.Refresh(context.Background()), skizze.ErrUnavailable))
+
+	// The metrics of the last successful refresh are kept.
+	expected := `
+# HELP skizze_sketch_cardinality Estimated number of distinct values in a cardinality sketch.
+# TYPE skizze_sketch_cardinality gauge
+skizze_sketch_cardinality{sketch="visitors"} 5
+# HELP skizze_refresh_errors_total Number of failed refreshes of the sketch metrics.
+# TYPE skizze_refresh_errors_total counter
+skizze_refresh_errors_total 1
+`
+	assert.Nil(testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"skizze_sketch_cardinality", "skizze_refresh_errors_total"))
+}
+
+func TestCollectorInvalidUTF8(t *testing.T) {
+	assert := assert.New(t)
+
+	l := skizze.NewLocal()
+	_, err := l.CreateSketch("pages", skizze.Ranking, nil)
+	assert.Nil(err)
+	assert.Nil(l.AddToSketch("pages", skizze.Ranking, "/\xff", "/\xff", "/"))
+
+	var errs []error
+	c := skizzeprom.NewCollector(l, skizzeprom.Options{OnError: func(err error) { errs = append(errs, err) }})
+	assert.Nil(c.Refresh(context.Background()))
+	assert.Equal(1, len(errs))
+
+	expected := `
+# HELP skizze_sketch_ranking_count Count of a top ranked value in a ranking sketch.
+# TYPE skizze_sketch_ranking_count gauge
+skizze_sketch_ranking_count{rank="1",sketch="pages",value="/�"} 2
+skizze_sketch_ranking_count{rank="2",sketch="pages",value="/"} 1
+`
+	assert.Nil(testutil.CollectAndCompare(c, strings.NewReader(expected), "skizze_sketch_ranking_count"))
+}
+
+func TestCollectorDuplicateLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	// Both names are exported as "users\uFFFD".
+	l := skizze.NewLocal()
+	_, err := l.CreateSketch("users\xfe", skizze.Cardinality, nil)
+	assert.Nil(err)
+	_, err = l.CreateSketch("users\xff", skizze.Cardinality, nil)
+	assert.Nil(err)
+	assert.Nil(l.AddToSketch("users\xfe", skizze.Cardinality, "alvin"))
+
+	var errs []error
+	c := skizzeprom.NewCollector(l, skizzeprom.Options{OnError: func(err error) { errs = append(errs, err) }})
+	assert.Nil(c.Refresh(context.Background()))
+	assert.Equal(`skipping metric with duplicate label values ["users�"]`, errs[len(errs)-1].Error())
+
+	expected := `
+# HELP skizze_sketch_cardinality Estimated number of distinct values in a cardinality sketch.
+# TYPE skizze_sketch_cardinality gauge
+skizze_sketch_cardinality{sketch="users�"} 1
+`
+	assert.Nil(testutil.CollectAndCompare(c, strings.NewReader(expected), "skizze_sketch_cardinality"))
+}
@@ -0,0 +1,349 @@
+package skizzetest
+
+import (
+	"errors"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/skizzehq/goskizze/protobuf"
+	"github.com/skizzehq/goskizze/skizze"
+)
+
+// Fake is a stateful implementation of pb.SkizzeServer. Domains and sketches are kept
+// in a skizze.Local engine, so values added are reflected in subsequent queries.
+type Fake struct {
+	// Engine holds the state of the fake. It may be used directly to seed or inspect
+	// data without going through gRPC.
+	Engine *skizze.Local
+}
+
+// NewFake returns a Fake with no domains or sketches.
+func NewFake() *Fake {
+	return &Fake{Engine: skizze.NewLocal()}
+}
+
+// toStatus converts an error from the engine into the gRPC status Skizze would return.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	var serr *skizze.Error
+	if errors.As(err, &serr) {
+		if _, ok := status.FromError(serr.Err); ok {
+			return serr.Err
+		}
+		return status.Error(codes.InvalidArgument, serr.Err.Error())
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+func sketchTypeFromRaw(t pb.SketchType) skizze.SketchType {
+	switch t {
+	case pb.SketchType_MEMB:
+		return skizze.Membership
+	case pb.SketchType_FREQ:
+		return skizze.Frequency
+	case pb.SketchType_RANK:
+		return skizze.Ranking
+	case pb.SketchType_CARD:
+		return skizze.Cardinality
+	}
+	return skizze.UnknownType
+}
+
+func rawFromSketchType(t skizze.SketchType) pb.SketchType {
+	switch t {
+	case skizze.Frequency:
+		return pb.SketchType_FREQ
+	case skizze.Ranking:
+		return pb.SketchType_RANK
+	case skizze.Cardinality:
+		return pb.SketchType_CARD
+	}
+	return pb.SketchType_MEMB
+}
+
+func propertiesFromRaw(r *pb.SketchProperties) *skizze.Properties {
+	if r == nil {
+		return nil
+	}
+	return &skizze.Properties{
+		MaxUniqueItems: r.GetMaxUniqueItems(),
+		ErrorRate:      r.GetErrorRate(),
+		Size:           r.GetSize(),
+	}
+}
+
+func rawFromSketch(s *skizze.Sketch) *pb.Sketch {
+	name := s.Name
+	t := rawFromSketchType(s.Type)
+	ret := &pb.Sketch{Name: &name, Type: &t}
+	if p := s.Properties; p != nil {
+		ret.Properties = &pb.SketchProperties{
+			MaxUniqueItems: &p.MaxUniqueItems,
+			ErrorRate:      &p.ErrorRate,
+			Size:           &p.Size,
+		}
+	}
+	if st := s.State; st != nil {
+		ret.State = &pb.SketchState{FillRate: &st.FillRate}
+		if !st.LastSnapshot.IsZero() {
+			ts := st.LastSnapshot.Unix()
+			ret.State.LastSnapshot = &ts
+		}
+	}
+	return ret
+}
+
+func rawFromDomain(d *skizze.Domain) *pb.Domain {
+	name := d.Name
+	ret := &pb.Domain{Name: &name}
+	for _, s := range d.Sketches {
+		ret.Sketches = append(ret.Sketches, rawFromSketch(s))
+	}
+	return ret
+}
+
+func rawFromSketches(sketches []*skizze.Sketch) *pb.ListReply {
+	ret := &pb.ListReply{}
+	for _, s := range sketches {
+		ret.Sketches = append(ret.Sketches, rawFromSketch(s))
+	}
+	return ret
+}
+
+func rawFromSnapshot(s *skizze.Snapshot) (pb.SnapshotStatus, *string) {
+	var st pb.SnapshotStatus
+	switch s.Status {
+	case skizze.InProgress:
+		st = pb.SnapshotStatus_IN_PROGRESS
+	case skizze.Successful:
+		st = pb.SnapshotStatus_SUCCESSFUL
+	case skizze.Failed:
+		st = pb.SnapshotStatus_FAILED
+	default:
+		st = pb.SnapshotStatus_PENDING
+	}
+	msg := s.Message
+	return st, &msg
+}
+
+// names returns the names of the sketches in a GetRequest.
+func names(in *pb.GetRequest) []string {
+	var ret []string
+	for _, s := range in.GetSketches() {
+		ret = append(ret, s.GetName())
+	}
+	return ret
+}
+
+// CreateSnapshot implements pb.SkizzeServer.
+func (f *Fake) CreateSnapshot(ctx context.Context, in *pb.CreateSnapshotRequest) (*pb.CreateSnapshotReply, error) {
+	s, err := f.Engine.CreateSnapshotContext(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	st, msg := rawFromSnapshot(s)
+	return &pb.CreateSnapshotReply{Status: &st, StatusMessage: msg}, nil
+}
+
+// GetSnapshot implements pb.SkizzeServer.
+func (f *Fake) GetSnapshot(ctx context.Context, in *pb.GetSnapshotRequest) (*pb.GetSnapshotReply, error) {
+	s, err := f.Engine.GetSnapshotContext(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	st, msg := rawFromSnapshot(s)
+	ret := &pb.GetSnapshotReply{Status: &st, StatusMessage: msg}
+	if !s.Timestamp.IsZero() {
+		ts := s.Timestamp.Unix()
+		ret.Timestamp = &ts
+	}
+	return ret, nil
+}
+
+// List implements pb.SkizzeServer.
+func (f *Fake) List(ctx context.Context, in *pb.ListRequest) (*pb.ListReply, error) {
+	sketches, err := f.Engine.ListSketchesContext(ctx, sketchTypeFromRaw(in.GetType()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return rawFromSketches(sketches), nil
+}
+
+// ListAll implements pb.SkizzeServer.
+func (f *Fake) ListAll(ctx context.Context, in *pb.Empty) (*pb.ListReply, error) {
+	sketches, err := f.Engine.ListAllContext(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return rawFromSketches(sketches), nil
+}
+
+// ListDomains implements pb.SkizzeServer.
+func (f *Fake) ListDomains(ctx context.Context, in *pb.Empty) (*pb.ListDomainsReply, error) {
+	domains, err := f.Engine.ListDomainsContext(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListDomainsReply{Names: domains}, nil
+}
+
+// CreateDomain implements pb.SkizzeServer.
+func (f *Fake) CreateDomain(ctx context.Context, in *pb.Domain) (*pb.Domain, error) {
+	var d *skizze.Domain
+	var err error
+	if len(in.GetSketches()) == 0 {
+		d, err = f.Engine.CreateDomainContext(ctx, in.GetName())
+	} else {
+		props := &skizze.DomainProperties{}
+		for _, s := range in.GetSketches() {
+			p := propertiesFromRaw(s.GetProperties())
+			if p == nil {
+				continue
+			}
+			switch s.GetType() {
+			case pb.SketchType_MEMB:
+				props.MembershipProperties = *p
+			case pb.SketchType_FREQ:
+				props.FrequencyProperties = *p
+			case pb.SketchType_RANK:
+				props.RankingsProperties = *p
+			}
+		}
+		d, err = f.Engine.CreateDomainWithPropertiesContext(ctx, in.GetName(), props)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return rawFromDomain(d), nil
+}
+
+// DeleteDomain implements pb.SkizzeServer.
+func (f *Fake) DeleteDomain(ctx context.Context, in *pb.Domain) (*pb.Empty, error) {
+	if err := f.Engine.DeleteDomainContext(ctx, in.GetName()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.Empty{}, nil
+}
+
+// GetDomain implements pb.SkizzeServer.
+func (f *Fake) GetDomain(ctx context.Context, in *pb.Domain) (*pb.Domain, error) {
+	d, err := f.Engine.GetDomainContext(ctx, in.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return rawFromDomain(d), nil
+}
+
+// CreateSketch implements pb.SkizzeServer.
+func (f *Fake) CreateSketch(ctx context.Context, in *pb.Sketch) (*pb.Sketch, error) {
+	s, err := f.Engine.CreateSketchContext(ctx, in.GetName(), sketchTypeFromRaw(in.GetType()), propertiesFromRaw(in.GetProperties()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return rawFromSketch(s), nil
+}
+
+// DeleteSketch implements pb.SkizzeServer.
+func (f *Fake) DeleteSketch(ctx context.Context, in *pb.Sketch) (*pb.Empty, error) {
+	if err := f.Engine.DeleteSketchContext(ctx, in.GetName(), sketchTypeFromRaw(in.GetType())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.Empty{}, nil
+}
+
+// GetSketch implements pb.SkizzeServer.
+func (f *Fake) GetSketch(ctx context.Context, in *pb.Sketch) (*pb.Sketch, error) {
+	s, err := f.Engine.GetSketchContext(ctx, in.GetName(), sketchTypeFromRaw(in.GetType()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return rawFromSketch(s), nil
+}
+
+// Add implements pb.SkizzeServer.
+func (f *Fake) Add(ctx context.Context, in *pb.AddRequest) (*pb.AddReply, error) {
+	var err error
+	if in.Domain != nil {
+		err = f.Engine.AddToDomainContext(ctx, in.GetDomain().GetName(), in.GetValues()...)
+	} else {
+		s := in.GetSketch()
+		err = f.Engine.AddToSketchContext(ctx, s.GetName(), sketchTypeFromRaw(s.GetType()), in.GetValues()...)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.AddReply{}, nil
+}
+
+// GetMembership implements pb.SkizzeServer.
+func (f *Fake) GetMembership(ctx context.Context, in *pb.GetRequest) (*pb.GetMembershipReply, error) {
+	results, err := f.Engine.GetMultiMembershipContext(ctx, names(in), in.GetValues()...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	ret := &pb.GetMembershipReply{}
+	for _, result := range results {
+		r := &pb.MembershipResult{}
+		for _, m := range result {
+			value, isMember := m.Value, m.IsMember
+			r.Memberships = append(r.Memberships, &pb.Membership{Value: &value, IsMember: &isMember})
+		}
+		ret.Results = append(ret.Results, r)
+	}
+	return ret, nil
+}
+
+// GetFrequency implements pb.SkizzeServer.
+func (f *Fake) GetFrequency(ctx context.Context, in *pb.GetRequest) (*pb.GetFrequencyReply, error) {
+	results, err := f.Engine.GetMultiFrequencyContext(ctx, names(in), in.GetValues()...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	ret := &pb.GetFrequencyReply{}
+	for _, result := range results {
+		r := &pb.FrequencyResult{}
+		for _, m := range result {
+			value, count := m.Value, m.Count
+			r.Frequencies = append(r.Frequencies, &pb.Frequency{Value: &value, Count: &count})
+		}
+		ret.Results = append(ret.Results, r)
+	}
+	return ret, nil
+}
+
+// GetCardinality implements pb.SkizzeServer.
+func (f *Fake) GetCardinality(ctx context.Context, in *pb.GetRequest) (*pb.GetCardinalityReply, error) {
+	results, err := f.Engine.GetMultiCardinalityContext(ctx, names(in))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	ret := &pb.GetCardinalityReply{}
+	for i := range results {
+		ret.Results = append(ret.Results, &pb.CardinalityResult{Cardinality: &results[i]})
+	}
+	return ret, nil
+}
+
+// GetRankings implements pb.SkizzeServer.
+func (f *Fake) GetRankings(ctx context.Context, in *pb.GetRequest) (*pb.GetRankingsReply, error) {
+	results, err := f.Engine.GetMultiRankingsContext(ctx, names(in))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	ret := &pb.GetRankingsReply{}
+	for _, result := range results {
+		r := &pb.RankingsResult{}
+		for _, m := range result {
+			value, count := m.Value, m.Count
+			r.Rankings = append(r.Rankings, &pb.Rank{Value: &value, Count: &count})
+		}
+		ret.Results = append(ret.Results, r)
+	}
+	return ret, nil
+}
+
+var _ pb.SkizzeServer = (*Fake)(nil)
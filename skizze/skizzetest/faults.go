@@ -0,0 +1,158 @@
+package skizzetest
+
+import (
+	"net"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/skizzehq/goskizze/protobuf"
+)
+
+// AllMethods may be passed to SetFault to inject a fault into every method.
+const AllMethods = "*"
+
+// Fault describes failures injected into calls of a method. Rates are probabilities
+// from 0 (never) to 1 (every call).
+type Fault struct {
+	// Latency is added to each call, plus a random duration up to LatencyJitter.
+	Latency       time.Duration
+	LatencyJitter time.Duration
+
+	// Code is returned instead of handling the call, at ErrorRate.
+	Code      codes.Code
+	ErrorRate float64
+
+	// DropRate is the rate at which the client's connection is closed mid-call.
+	DropRate float64
+
+	// TruncateRate is the rate at which query replies are returned with no results.
+	TruncateRate float64
+
+	// Times limits the fault to the next Times calls. Zero means no limit.
+	Times int
+}
+
+// SetFault injects f into calls of method, e.g. "GetMembership" or AllMethods,
+// replacing any fault previously set for method. A fault set for a specific method takes
+// precedence over AllMethods.
+func (s *Server) SetFault(method string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[method] = &f
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = make(map[string]*Fault)
+}
+
+// SetSeed seeds the random source used for latency jitter and fault rates, making
+// faults reproducible.
+func (s *Server) SetSeed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rand.Seed(seed)
+}
+
+// plan decides which faults apply to a call of method.
+type plan struct {
+	latency  time.Duration
+	err      error
+	drop     bool
+	truncate bool
+}
+
+func (s *Server) plan(method string) (p plan) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.faults[method]
+	if !ok {
+		if f, ok = s.faults[AllMethods]; !ok {
+			return p
+		}
+	}
+	if f.Times > 0 {
+		if f.Times--; f.Times == 0 {
+			for k, v := range s.faults {
+				if v == f {
+					delete(s.faults, k)
+				}
+			}
+		}
+	}
+
+	p.latency = f.Latency
+	if f.LatencyJitter > 0 {
+		p.latency += time.Duration(s.rand.Int63n(int64(f.LatencyJitter)))
+	}
+	if f.ErrorRate > 0 && s.rand.Float64() < f.ErrorRate {
+		p.err = status.Errorf(f.Code, "skizzetest: injected %v error", f.Code)
+	}
+	p.drop = f.DropRate > 0 && s.rand.Float64() < f.DropRate
+	p.truncate = f.TruncateRate > 0 && s.rand.Float64() < f.TruncateRate
+	return p
+}
+
+func (s *Server) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	method := info.FullMethod[len("/protobuf.Skizze/"):]
+	p := s.plan(method)
+
+	if p.latency > 0 {
+		t := time.NewTimer(p.latency)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-t.C:
+		}
+	}
+
+	if p.drop {
+		s.mu.Lock()
+		l := s.listener
+		s.mu.Unlock()
+
+		// Connections over bufconn share a remote address, so all of them are dropped.
+		var addr net.Addr
+		if pr, ok := peer.FromContext(ctx); ok && !s.useBufconn {
+			addr = pr.Addr
+		}
+		l.drop(addr)
+		return nil, status.Error(codes.Unavailable, "skizzetest: connection dropped")
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	reply, err := handler(ctx, req)
+	if err == nil && p.truncate {
+		truncate(reply)
+	}
+	return reply, err
+}
+
+// truncate removes the results from a query reply.
+func truncate(reply interface{}) {
+	switch r := reply.(type) {
+	case *pb.GetMembershipReply:
+		r.Results = nil
+	case *pb.GetFrequencyReply:
+		r.Results = nil
+	case *pb.GetRankingsReply:
+		r.Results = nil
+	case *pb.GetCardinalityReply:
+		r.Results = nil
+	case *pb.ListReply:
+		r.Sketches = nil
+	case *pb.ListDomainsReply:
+		r.Names = nil
+	}
+}
@@ -0,0 +1,142 @@
+package skizzetest_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/skizzehq/goskizze/skizze"
+	"github.com/skizzehq/goskizze/skizze/skizzetest"
+)
+
+func TestFaultError(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := skizzetest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+	defer c.Close()
+
+	srv.SetFault("ListDomains", skizzetest.Fault{Code: codes.NotFound, ErrorRate: 1, Times: 2})
+
+	for i := 0; i < 2; i++ {
+		_, err := c.ListDomains()
+		assert.True(errors.Is(err, skizze.ErrNotFound))
+	}
+	_, err := c.ListDomains()
+	assert.Nil(err)
+
+	// Other methods are unaffected.
+	_, err = c.ListAll()
+	assert.Nil(err)
+}
+
+func TestFaultErrorRate(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := skizzetest.NewBufconnServer()
+	defer srv.Close()
+	c := srv.Client()
+	defer c.Close()
+
+	srv.SetSeed(1)
+	srv.SetFault(skizzetest.AllMethods, skizzetest.Fault{Code: codes.Unavailable, ErrorRate: 0.5})
+
+	failed := 0
+	for i := 0; i < 100; i++ {
+		if _, err := c.ListDomains(); err != nil {
+			failed++
+		}
+	}
+	assert.True(failed > 30 && failed < 70, "failed: %d", failed)
+
+	srv.ClearFaults()
+	_, err := c.ListDomains()
+	assert.Nil(err)
+}
+
+func TestFaultLatency(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := skizzetest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+	defer c.Close()
+
+	srv.SetFault("ListDomains", skizzetest.Fault{Latency: 50 * time.Millisecond, LatencyJitter: 10 * time.Millisecond})
+
+	start := time.Now()
+	_, err := c.ListDomains()
+	assert.Nil(err)
+	assert.True(time.Since(start) >= 50*time.Millisecond)
+}
+
+func TestFaultTruncate(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := skizzetest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+	defer c.Close()
+
+	_, err := c.CreateDomain("testdomain")
+	assert.Nil(err)
+
+	srv.SetFault("GetCardinality", skizzetest.Fault{TruncateRate: 1})
+	_, err = c.GetCardinality("testdomain")
+	assert.True(errors.Is(err, skizze.ErrMalformedReply))
+}
+
+func TestFaultDrop(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := skizzetest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+	defer c.Close()
+
+	srv.SetFault("ListDomains", skizzetest.Fault{DropRate: 1, Times: 1})
+	_, err := c.ListDomains()
+	assert.True(errors.Is(err, skizze.ErrUnavailable))
+
+	// The client reconnects once the fault has been used up.
+	assert.Eventually(func() bool {
+		_, err := c.ListDomains()
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRestart(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, srv := range []*skizzetest.Server{skizzetest.NewServer(), skizzetest.NewBufconnServer()} {
+		c := srv.Client()
+
+		_, err := c.CreateDomain("testdomain")
+		assert.Nil(err)
+
+		srv.Stop()
+		_, err = c.ListDomains()
+		assert.True(errors.Is(err, skizze.ErrUnavailable))
+
+		srv.Start()
+		var domains []string
+		assert.Eventually(func() bool {
+			domains, err = c.ListDomains()
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal([]string{"testdomain"}, domains)
+
+		srv.Restart()
+		assert.Eventually(func() bool {
+			_, err := c.GetDomain("testdomain")
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+
+		c.Close()
+		srv.Close()
+	}
+}
@@ -0,0 +1,212 @@
+// Package skizzetest provides a fake Skizze server for integration tests.
+//
+// Example:
+//
+//     func TestCounting(t *testing.T) {
+//       srv := skizzetest.NewServer()
+//       defer srv.Close()
+//
+//       client := srv.Client()
+//       defer client.Close()
+//
+//       client.CreateDomain("users")
+//       client.AddToDomain("users", "alvin", "simon")
+//       card, _ := client.GetCardinality("users") // 2
+//     }
+//
+// Faults such as latency, errors and dropped connections can be injected per method
+// with SetFault, and the server can be stopped and restarted with its state intact.
+//
+package skizzetest
+
+import (
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/skizzehq/goskizze/protobuf"
+	"github.com/skizzehq/goskizze/skizze"
+)
+
+const bufconnSize = 1024 * 1024
+
+// Server runs a Fake on a local gRPC server.
+type Server struct {
+	// Addr is the address the server listens on, e.g. "127.0.0.1:41235". It is
+	// "bufconn" for servers started with NewBufconnServer.
+	Addr string
+
+	// Fake is the Skizze implementation served.
+	Fake *Fake
+
+	useBufconn bool
+
+	mu       sync.Mutex
+	server   *grpc.Server
+	listener *trackingListener
+	bufconn  *bufconn.Listener
+	faults   map[string]*Fault
+	rand     *rand.Rand
+}
+
+// NewServer starts a Server listening on a random local port. It panics if no port is
+// available.
+func NewServer() *Server {
+	s := newServer()
+	s.Addr = "127.0.0.1:0"
+	s.Start()
+	return s
+}
+
+// NewBufconnServer starts a Server on an in-memory connection, avoiding the network
+// entirely. Connect to it with Client or DialOptions.
+func NewBufconnServer() *Server {
+	s := newServer()
+	s.Addr = "bufconn"
+	s.useBufconn = true
+	s.Start()
+	return s
+}
+
+func newServer() *Server {
+	return &Server{
+		Fake:   NewFake(),
+		faults: make(map[string]*Fault),
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Start serves the Fake on the server's address. It is only needed after Stop, and
+// panics if the address cannot be listened on.
+func (s *Server) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server != nil {
+		return
+	}
+
+	var l net.Listener
+	if s.useBufconn {
+		s.bufconn = bufconn.Listen(bufconnSize)
+		l = s.bufconn
+	} else {
+		var err error
+		if l, err = net.Listen("tcp", s.Addr); err != nil {
+			panic("skizzetest: failed to listen on " + s.Addr + ": " + err.Error())
+		}
+		s.Addr = l.Addr().String()
+	}
+
+	s.listener = newTrackingListener(l)
+	s.server = grpc.NewServer(grpc.UnaryInterceptor(s.intercept))
+	pb.RegisterSkizzeServer(s.server, s.Fake)
+	go s.server.Serve(s.listener)
+}
+
+// Stop stops serving and closes all connections, keeping the state of the Fake. Calls
+// made while stopped fail as unavailable.
+func (s *Server) Stop() {
+	s.mu.Lock()
+	server := s.server
+	s.server = nil
+	s.mu.Unlock()
+
+	if server != nil {
+		server.Stop()
+	}
+}
+
+// Restart stops and starts the server on the same address, dropping all connections.
+func (s *Server) Restart() {
+	s.Stop()
+	s.Start()
+}
+
+// DialOptions returns the options needed to connect to the server, for use with
+// skizze.Options.DialOptions.
+func (s *Server) DialOptions() []grpc.DialOption {
+	if !s.useBufconn {
+		return nil
+	}
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			s.mu.Lock()
+			l := s.bufconn
+			s.mu.Unlock()
+			return l.DialContext(ctx)
+		}),
+	}
+}
+
+// Client returns a new insecure client connected to the server. It panics if the client
+// cannot be created.
+func (s *Server) Client() *skizze.Client {
+	c, err := skizze.Dial(s.Addr, skizze.Options{Insecure: true, DialOptions: s.DialOptions()})
+	if err != nil {
+		panic("skizzetest: failed to dial server: " + err.Error())
+	}
+	return c
+}
+
+// Close stops the server, closing all connections to it.
+func (s *Server) Close() {
+	s.Stop()
+}
+
+// trackingListener records open accepted connections so they can be dropped.
+type trackingListener struct {
+	net.Listener
+
+	mu    sync.Mutex
+	conns map[net.Conn]bool
+}
+
+func newTrackingListener(l net.Listener) *trackingListener {
+	return &trackingListener{Listener: l, conns: make(map[net.Conn]bool)}
+}
+
+func (l *trackingListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	tc := &trackedConn{Conn: c, l: l}
+	l.mu.Lock()
+	l.conns[tc] = true
+	l.mu.Unlock()
+	return tc, nil
+}
+
+// drop closes the connections whose remote address is addr.
+func (l *trackingListener) drop(addr net.Addr) {
+	var conns []net.Conn
+	l.mu.Lock()
+	for c := range l.conns {
+		if addr == nil || c.RemoteAddr().String() == addr.String() {
+			conns = append(conns, c)
+		}
+	}
+	l.mu.Unlock()
+	for _, c := range conns {
+		c.Close()
+	}
+}
+
+// trackedConn is a connection accepted by a trackingListener, which forgets it once it
+// is closed.
+type trackedConn struct {
+	net.Conn
+	l *trackingListener
+}
+
+func (c *trackedConn) Close() error {
+	c.l.mu.Lock()
+	delete(c.l.conns, c)
+	c.l.mu.Unlock()
+	return c.Conn.Close()
+}
@@ -0,0 +1,90 @@
+package skizzetest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skizzehq/goskizze/skizze"
+	"github.com/skizzehq/goskizze/skizze/skizzetest"
+)
+
+func testServer(t *testing.T, srv *skizzetest.Server) {
+	assert := assert.New(t)
+	defer srv.Close()
+
+	c := srv.Client()
+	defer c.Close()
+
+	d, err := c.CreateDomainWithProperties("testdomain", &skizze.DomainProperties{
+		MembershipProperties: skizze.Properties{MaxUniqueItems: 1000, ErrorRate: 0.01},
+		FrequencyProperties:  skizze.Properties{MaxUniqueItems: 1000, ErrorRate: 0.01},
+		RankingsProperties:   skizze.Properties{Size: 2},
+	})
+	assert.Nil(err)
+	assert.Equal(4, len(d.Sketches))
+
+	_, err = c.CreateDomain("testdomain")
+	assert.True(errors.Is(err, skizze.ErrAlreadyExists))
+
+	assert.Nil(c.AddToDomain("testdomain", "alvin", "simon", "theodore", "alvin"))
+
+	membs, err := c.GetMembership("testdomain", "alvin", "gary")
+	assert.Nil(err)
+	assert.True(membs[0].IsMember)
+	assert.False(membs[1].IsMember)
+
+	freqs, err := c.GetMultiFrequency([]string{"testdomain", "testdomain"}, "alvin")
+	assert.Nil(err)
+	assert.Equal(int64(2), freqs[1][0].Count)
+
+	ranks, err := c.GetRankings("testdomain")
+	assert.Nil(err)
+	assert.Equal(2, len(ranks))
+	assert.Equal("alvin", ranks[0].Value)
+
+	card, err := c.GetCardinality("testdomain")
+	assert.Nil(err)
+	assert.Equal(int64(3), card)
+
+	_, err = c.CreateSketch("ranks", skizze.Ranking, &skizze.Properties{Size: 0})
+	assert.True(errors.Is(err, skizze.ErrInvalidProperties))
+
+	s, err := c.CreateSketch("ranks", skizze.Ranking, &skizze.Properties{Size: 10})
+	assert.Nil(err)
+	assert.Equal(skizze.Ranking, s.Type)
+	assert.Equal(int64(10), s.Properties.Size)
+
+	all, err := c.ListAll()
+	assert.Nil(err)
+	assert.Equal(5, len(all))
+
+	_, err = c.CreateSnapshot()
+	assert.Nil(err)
+	snap, err := c.GetSnapshot()
+	assert.Nil(err)
+	assert.Equal(skizze.Successful, snap.Status)
+	assert.False(snap.Timestamp.IsZero())
+
+	s, err = c.GetSketch("ranks", skizze.Ranking)
+	assert.Nil(err)
+	assert.Equal(snap.Timestamp, s.State.LastSnapshot)
+
+	assert.Nil(c.DeleteDomain("testdomain"))
+	_, err = c.GetCardinality("testdomain")
+	assert.True(errors.Is(err, skizze.ErrNotFound))
+
+	// The engine reflects changes made through the client.
+	domains, err := srv.Fake.Engine.ListDomains()
+	assert.Nil(err)
+	assert.Equal(0, len(domains))
+}
+
+func TestServer(t *testing.T) {
+	testServer(t, skizzetest.NewServer())
+}
+
+func TestBufconnServer(t *testing.T) {
+	testServer(t, skizzetest.NewBufconnServer())
+}
@@ -1,7 +1,7 @@
 package skizze
 
 import (
-	"log"
+	"time"
 
 	pb "github.com/skizzehq/goskizze/protobuf"
 )
@@ -18,12 +18,38 @@ const (
 	Successful
 	// Failed indicates the last snapshot had an error.
 	Failed
+
+	// UnknownState is reported for snapshot states this client does not recognize, such
+	// as states added by a newer Skizze server.
+	UnknownState SnapshotState = -1
 )
 
 // Snapshot represents details of the a snapshot
 type Snapshot struct {
 	Status  SnapshotState
 	Message string
+
+	// Timestamp is the time the snapshot was taken. It is the zero time if the server
+	// did not report one.
+	Timestamp time.Time
+}
+
+func newSnapshotFromCreateReply(r *pb.CreateSnapshotReply) *Snapshot {
+	return &Snapshot{
+		Status:  snapshotStatusFromRaw(r.GetStatus()),
+		Message: r.GetStatusMessage(),
+	}
+}
+
+func newSnapshotFromGetReply(r *pb.GetSnapshotReply) *Snapshot {
+	ret := &Snapshot{
+		Status:  snapshotStatusFromRaw(r.GetStatus()),
+		Message: r.GetStatusMessage(),
+	}
+	if ts := r.GetTimestamp(); ts != 0 {
+		ret.Timestamp = time.Unix(ts, 0)
+	}
+	return ret
 }
 
 func snapshotStatusFromRaw(s pb.SnapshotStatus) SnapshotState {
@@ -37,7 +63,6 @@ func snapshotStatusFromRaw(s pb.SnapshotStatus) SnapshotState {
 	case pb.SnapshotStatus_FAILED:
 		return Failed
 	default:
-		log.Panicf("Snapshot status %v unknown", s)
-		return 0
+		return UnknownState
 	}
 }
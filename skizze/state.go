@@ -0,0 +1,28 @@
+package skizze
+
+import (
+	"time"
+
+	pb "github.com/skizzehq/goskizze/protobuf"
+)
+
+// SketchState reports the runtime state of a Sketch.
+type SketchState struct {
+	// FillRate is how full the sketch is, from 0.0 (empty) to 1.0 (saturated).
+	FillRate float32
+
+	// LastSnapshot is the time the sketch was last snapshotted. It is the zero time if
+	// the sketch has never been snapshotted.
+	LastSnapshot time.Time
+}
+
+func newStateFromRaw(r *pb.SketchState) *SketchState {
+	if r == nil {
+		return nil
+	}
+	ret := &SketchState{FillRate: r.GetFillRate()}
+	if ts := r.GetLastSnapshot(); ts != 0 {
+		ret.LastSnapshot = time.Unix(ts, 0)
+	}
+	return ret
+}
@@ -0,0 +1,245 @@
+package skizze
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// defaultWatchInterval is used for non-positive polling intervals.
+var defaultWatchInterval = time.Second
+
+// defaultWatchMaxBackoff caps the delay between polls after errors, unless the interval
+// is longer.
+var defaultWatchMaxBackoff = time.Minute
+
+// RankingsChangeKind is the kind of a RankingsChange.
+type RankingsChangeKind int
+
+const (
+	// RankEntered is reported for a value which entered the rankings.
+	RankEntered RankingsChangeKind = iota
+	// RankLeft is reported for a value which left the rankings.
+	RankLeft
+	// RankMoved is reported for a value whose rank changed. Its count may have changed
+	// too.
+	RankMoved
+	// RankCountChanged is reported for a value whose count changed but not its rank.
+	RankCountChanged
+)
+
+var rankingsChangeKindNames = map[RankingsChangeKind]string{
+	RankEntered:      "entered",
+	RankLeft:         "left",
+	RankMoved:        "moved",
+	RankCountChanged: "count changed",
+}
+
+func (k RankingsChangeKind) String() string {
+	if name, ok := rankingsChangeKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("RankingsChangeKind(%d)", int(k))
+}
+
+// RankingsChange is a change of a value in the rankings of a sketch. Ranks start at 1;
+// the rank and count of a value not in the rankings are 0.
+type RankingsChange struct {
+	Kind      RankingsChangeKind
+	Value     string
+	Rank      int
+	PrevRank  int
+	Count     int64
+	PrevCount int64
+}
+
+// RankingsEvent reports the changes of the rankings of a sketch since the previous event
+// for that sketch, or an error.
+type RankingsEvent struct {
+	// Sketch is the name of the ranking sketch. It is empty for errors.
+	Sketch string
+	// Rankings are the current rankings of the sketch.
+	Rankings []*RankingsResult
+	// Changes are ordered by current rank, followed by the values which left.
+	Changes []RankingsChange
+	// Err is the error of the last failed poll.
+	Err error
+}
+
+// WatchRankings polls the ranking sketch name every interval and sends an event on the
+// returned channel whenever its rankings change. The first event has every ranked value
+// as entered. Events are coalesced while the receiver is busy: the next event received
+// covers every change since the previous one, so none are lost and the receiver never
+// falls behind. Failed polls are retried with an increasing backoff and reported by an
+// event with Err set, unless a later poll succeeds first. The channel is closed when ctx
+// is done. A non-positive interval polls every second.
+func (c *Client) WatchRankings(ctx context.Context, name string, interval time.Duration) <-chan RankingsEvent {
+	return watchRankings(ctx, c, []string{name}, interval)
+}
+
+// WatchMultiRankings is like WatchRankings but watches several sketches, querying them
+// together.
+func (c *Client) WatchMultiRankings(ctx context.Context, names []string, interval time.Duration) <-chan RankingsEvent {
+	return watchRankings(ctx, c, names, interval)
+}
+
+// WatchRankings is like Client.WatchRankings.
+func (l *Local) WatchRankings(ctx context.Context, name string, interval time.Duration) <-chan RankingsEvent {
+	return watchRankings(ctx, l, []string{name}, interval)
+}
+
+// WatchMultiRankings is like Client.WatchMultiRankings.
+func (l *Local) WatchMultiRankings(ctx context.Context, names []string, interval time.Duration) <-chan RankingsEvent {
+	return watchRankings(ctx, l, names, interval)
+}
+
+type rankingsWatcher struct {
+	s     Sketcher
+	names []string
+
+	// sent holds the rankings of the last event sent for each sketch, and latest those of
+	// the last successful poll.
+	sent   map[string][]*RankingsResult
+	latest map[string][]*RankingsResult
+	err    error
+}
+
+func watchRankings(ctx context.Context, s Sketcher, names []string, interval time.Duration) <-chan RankingsEvent {
+	w := &rankingsWatcher{
+		s:      s,
+		names:  names,
+		sent:   make(map[string][]*RankingsResult),
+		latest: make(map[string][]*RankingsResult),
+	}
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	ch := make(chan RankingsEvent)
+	go w.run(ctx, ch, interval)
+	return ch
+}
+
+func (w *rankingsWatcher) run(ctx context.Context, ch chan<- RankingsEvent, interval time.Duration) {
+	defer close(ch)
+
+	max := defaultWatchMaxBackoff
+	if interval > max {
+		max = interval
+	}
+	backoff := RetryPolicy{InitialBackoff: interval, MaxBackoff: max, Multiplier: 2, Jitter: 0.2}
+	failures := 0
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		var out chan<- RankingsEvent
+		ev, ok := w.pending()
+		if ok {
+			out = ch
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case out <- ev:
+			if ev.Err != nil {
+				w.err = nil
+			} else {
+				w.sent[ev.Sketch] = ev.Rankings
+			}
+		case <-timer.C:
+			if err := w.poll(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				failures++
+				w.err = err
+				timer.Reset(backoff.backoff(failures))
+			} else {
+				failures = 0
+				w.err = nil
+				timer.Reset(interval)
+			}
+		}
+	}
+}
+
+func (w *rankingsWatcher) poll(ctx context.Context) error {
+	if len(w.names) == 1 {
+		r, err := w.s.GetRankingsContext(ctx, w.names[0])
+		if err != nil {
+			return err
+		}
+		w.latest[w.names[0]] = r
+		return nil
+	}
+
+	results, err := w.s.GetMultiRankingsContext(ctx, w.names)
+	if err != nil {
+		return err
+	}
+	if err := checkResults(len(results), len(w.names)); err != nil {
+		return newClientError("GetMultiRankings", err)
+	}
+	for i, name := range w.names {
+		w.latest[name] = results[i]
+	}
+	return nil
+}
+
+// pending returns the next event to send: the last error, or the changes of the first
+// sketch whose latest rankings differ from those sent.
+func (w *rankingsWatcher) pending() (RankingsEvent, bool) {
+	if w.err != nil {
+		return RankingsEvent{Err: w.err}, true
+	}
+	for _, name := range w.names {
+		latest, ok := w.latest[name]
+		if !ok {
+			continue
+		}
+		changes := diffRankings(w.sent[name], latest)
+		if _, sent := w.sent[name]; len(changes) > 0 || !sent {
+			return RankingsEvent{Sketch: name, Rankings: latest, Changes: changes}, true
+		}
+	}
+	return RankingsEvent{}, false
+}
+
+// diffRankings returns the changes from prev to cur.
+func diffRankings(prev, cur []*RankingsResult) []RankingsChange {
+	type rank struct {
+		rank  int
+		count int64
+	}
+	old := make(map[string]rank, len(prev))
+	for i, r := range prev {
+		old[r.Value] = rank{i + 1, r.Count}
+	}
+
+	var changes []RankingsChange
+	for i, r := range cur {
+		c := RankingsChange{Value: r.Value, Rank: i + 1, Count: r.Count}
+		o, ok := old[r.Value]
+		delete(old, r.Value)
+		switch {
+		case !ok:
+			c.Kind = RankEntered
+		case o.rank != c.Rank:
+			c.Kind = RankMoved
+		case o.count != c.Count:
+			c.Kind = RankCountChanged
+		default:
+			continue
+		}
+		c.PrevRank, c.PrevCount = o.rank, o.count
+		changes = append(changes, c)
+	}
+	for _, r := range prev {
+		if o, ok := old[r.Value]; ok {
+			changes = append(changes, RankingsChange{Kind: RankLeft, Value: r.Value, PrevRank: o.rank, PrevCount: o.count})
+		}
+	}
+	return changes
+}
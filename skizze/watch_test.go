@@ -0,0 +1,112 @@
+package skizze_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	pb "github.com/skizzehq/goskizze/protobuf"
+	. "github.com/skizzehq/goskizze/skizze"
+)
+
+func nextEvent(t *testing.T, ch <-chan RankingsEvent) RankingsEvent {
+	select {
+	case ev := <-ch:
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("no rankings event")
+		return RankingsEvent{}
+	}
+}
+
+func TestWatchRankings(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := NewLocal()
+	_, err := l.CreateSketch("pages", Ranking, nil)
+	assert.Nil(err)
+	assert.Nil(l.AddToSketch("pages", Ranking, "/", "/", "/about"))
+
+	ch := l.WatchRankings(ctx, "pages", 5*time.Millisecond)
+	ev := nextEvent(t, ch)
+	assert.Nil(ev.Err)
+	assert.Equal("pages", ev.Sketch)
+	assert.Equal([]RankingsChange{
+		{Kind: RankEntered, Value: "/", Rank: 1, Count: 2},
+		{Kind: RankEntered, Value: "/about", Rank: 2, Count: 1},
+	}, ev.Changes)
+
+	// Changes made while the receiver is busy are coalesced into one event.
+	assert.Nil(l.AddToSketch("pages", Ranking, "/about", "/about", "/about"))
+	time.Sleep(50 * time.Millisecond)
+	assert.Nil(l.AddToSketch("pages", Ranking, "/contact"))
+	time.Sleep(50 * time.Millisecond)
+	ev = nextEvent(t, ch)
+	assert.Equal([]RankingsChange{
+		{Kind: RankMoved, Value: "/about", Rank: 1, PrevRank: 2, Count: 4, PrevCount: 1},
+		{Kind: RankMoved, Value: "/", Rank: 2, PrevRank: 1, Count: 2, PrevCount: 2},
+		{Kind: RankEntered, Value: "/contact", Rank: 3, Count: 1},
+	}, ev.Changes)
+	assert.Equal(3, len(ev.Rankings))
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestWatchMultiRankings(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := NewLocal()
+	_, err := l.CreateSketch("a", Ranking, nil)
+	assert.Nil(err)
+	assert.Nil(l.AddToSketch("a", Ranking, "/"))
+
+	// Errors are reported, then polling resumes once they are resolved.
+	ch := l.WatchMultiRankings(ctx, []string{"a", "b"}, 5*time.Millisecond)
+	ev := nextEvent(t, ch)
+	assert.True(errors.Is(ev.Err, ErrNotFound))
+	_, err = l.CreateSketch("b", Ranking, nil)
+	assert.Nil(err)
+
+	for ev = nextEvent(t, ch); ev.Err != nil; ev = nextEvent(t, ch) {
+	}
+	assert.Equal("a", ev.Sketch)
+	assert.Equal([]RankingsChange{{Kind: RankEntered, Value: "/", Rank: 1, Count: 1}}, ev.Changes)
+	ev = nextEvent(t, ch)
+	assert.Equal("b", ev.Sketch)
+	assert.Nil(ev.Changes)
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestWatchRankingsDefaultInterval(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, fs := getClient(t)
+	defer closeAll(c, fs)
+	fs.nextReply = &pb.GetRankingsReply{Results: []*pb.RankingsResult{{}}}
+
+	// A zero interval polls every second rather than continuously.
+	ch := c.WatchRankings(ctx, "pages", 0)
+	ev := nextEvent(t, ch)
+	assert.Nil(ev.Err)
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(int32(1), atomic.LoadInt32(&fs.calls))
+
+	cancel()
+	for range ch {
+	}
+}
@@ -0,0 +1,301 @@
+package skizze
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	defaultWindowBucket  = time.Hour
+	defaultWindowBuckets = 24
+)
+
+// WindowOptions configures a Windowed domain.
+type WindowOptions struct {
+	// Bucket is the period covered by each bucket domain. Defaults to 1h.
+	Bucket time.Duration
+
+	// Retention is how long buckets are kept after they end. Defaults to 24 buckets.
+	Retention time.Duration
+
+	// Ahead is the number of upcoming buckets created by Maintain, so that writes at the
+	// start of a bucket do not have to create it. Defaults to 0.
+	Ahead int
+
+	// Properties, if set, are used to create each bucket.
+	Properties *DomainProperties
+
+	// Now returns the current time. Defaults to time.Now; it may be replaced in tests.
+	Now func() time.Time
+}
+
+// Windowed is a domain split into time buckets, each a domain named after the start of its
+// bucket in UTC, e.g. "users:2026101803" for hourly buckets. Values are added to the
+// current bucket, and queries over a time range combine the buckets it covers.
+type Windowed struct {
+	s      Sketcher
+	name   string
+	layout string
+	opts   WindowOptions
+}
+
+// NewWindowed returns a Windowed domain called name, using s to manage the buckets.
+func NewWindowed(s Sketcher, name string, opts WindowOptions) *Windowed {
+	if opts.Bucket <= 0 {
+		opts.Bucket = defaultWindowBucket
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = time.Duration(defaultWindowBuckets) * opts.Bucket
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	var layout string
+	switch {
+	case opts.Bucket%(24*time.Hour) == 0:
+		layout = "20060102"
+	case opts.Bucket%time.Hour == 0:
+		layout = "2006010215"
+	case opts.Bucket%time.Minute == 0:
+		layout = "200601021504"
+	default:
+		layout = "20060102150405"
+	}
+	return &Windowed{s: s, name: name, layout: layout, opts: opts}
+}
+
+// BucketName returns the name of the bucket domain holding values added at t.
+func (w *Windowed) BucketName(t time.Time) string {
+	return w.name + ":" + t.UTC().Truncate(w.opts.Bucket).Format(w.layout)
+}
+
+// Add adds values to the current bucket, creating it if needed.
+func (w *Windowed) Add(ctx context.Context, values ...string) error {
+	return w.AddAt(ctx, w.opts.Now(), values...)
+}
+
+// AddAt adds values to the bucket of t, creating it if needed.
+func (w *Windowed) AddAt(ctx context.Context, t time.Time, values ...string) error {
+	bucket := w.BucketName(t)
+	err := w.s.AddToDomainContext(ctx, bucket, values...)
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if err := w.create(ctx, bucket); err != nil {
+		return err
+	}
+	return w.s.AddToDomainContext(ctx, bucket, values...)
+}
+
+func (w *Windowed) create(ctx context.Context, bucket string) error {
+	var err error
+	if w.opts.Properties != nil {
+		_, err = w.s.CreateDomainWithPropertiesContext(ctx, bucket, w.opts.Properties)
+	} else {
+		_, err = w.s.CreateDomainContext(ctx, bucket)
+	}
+	if errors.Is(err, ErrAlreadyExists) {
+		return nil
+	}
+	return err
+}
+
+// Maintain creates the current bucket and the next WindowOptions.Ahead buckets, and
+// deletes the buckets which ended more than WindowOptions.Retention ago.
+func (w *Windowed) Maintain(ctx context.Context) error {
+	now := w.opts.Now().UTC().Truncate(w.opts.Bucket)
+	for i := 0; i <= w.opts.Ahead; i++ {
+		if err := w.create(ctx, w.BucketName(now.Add(time.Duration(i)*w.opts.Bucket))); err != nil {
+			return err
+		}
+	}
+
+	buckets, err := w.buckets(ctx)
+	if err != nil {
+		return err
+	}
+	expiry := w.opts.Now().Add(-w.opts.Retention)
+	for start, bucket := range buckets {
+		if !start.Add(w.opts.Bucket).After(expiry) {
+			if err := w.s.DeleteDomainContext(ctx, bucket); err != nil && !errors.Is(err, ErrNotFound) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Run calls Maintain once per bucket until ctx is done, passing errors to onError if it
+// is not nil.
+func (w *Windowed) Run(ctx context.Context, onError func(error)) {
+	t := time.NewTicker(w.opts.Bucket)
+	defer t.Stop()
+	for {
+		if err := w.Maintain(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// buckets returns the existing bucket domains keyed by the start of their bucket.
+func (w *Windowed) buckets(ctx context.Context) (map[time.Time]string, error) {
+	domains, err := w.s.ListDomainsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prefix := w.name + ":"
+	ret := make(map[time.Time]string)
+	for _, d := range domains {
+		if !strings.HasPrefix(d, prefix) {
+			continue
+		}
+		start, err := time.Parse(w.layout, d[len(prefix):])
+		if err != nil {
+			continue
+		}
+		ret[start] = d
+	}
+	return ret, nil
+}
+
+// bucketsIn returns the names of the existing buckets which overlap [from, to), oldest
+// first.
+func (w *Windowed) bucketsIn(ctx context.Context, from, to time.Time) ([]string, error) {
+	buckets, err := w.buckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var starts []time.Time
+	for start := range buckets {
+		if start.Before(to) && start.Add(w.opts.Bucket).After(from) {
+			starts = append(starts, start)
+		}
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	ret := make([]string, len(starts))
+	for i, start := range starts {
+		ret[i] = buckets[start]
+	}
+	return ret, nil
+}
+
+// GetMembership tests values for membership in any bucket overlapping [from, to).
+func (w *Windowed) GetMembership(ctx context.Context, from, to time.Time, values ...string) ([]*MembershipResult, error) {
+	ret := make([]*MembershipResult, len(values))
+	for i, v := range values {
+		ret[i] = &MembershipResult{Value: v}
+	}
+	buckets, err := w.bucketsIn(ctx, from, to)
+	if err != nil || len(buckets) == 0 {
+		return ret, err
+	}
+
+	results, err := w.s.GetMultiMembershipContext(ctx, buckets, values...)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		for i, m := range r {
+			ret[i].IsMember = ret[i].IsMember || m.IsMember
+		}
+	}
+	return ret, nil
+}
+
+// GetFrequency sums the frequency of values over the buckets overlapping [from, to).
+func (w *Windowed) GetFrequency(ctx context.Context, from, to time.Time, values ...string) ([]*FrequencyResult, error) {
+	ret := make([]*FrequencyResult, len(values))
+	for i, v := range values {
+		ret[i] = &FrequencyResult{Value: v}
+	}
+	buckets, err := w.bucketsIn(ctx, from, to)
+	if err != nil || len(buckets) == 0 {
+		return ret, err
+	}
+
+	results, err := w.s.GetMultiFrequencyContext(ctx, buckets, values...)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		for i, f := range r {
+			ret[i].Count += f.Count
+		}
+	}
+	return ret, nil
+}
+
+// GetRankings merges the rankings of the buckets overlapping [from, to), summing the
+// counts of each value. As each bucket only tracks its own top values, the result is
+// approximate. It has at most as many values as the ranking sketch of a bucket tracks.
+func (w *Windowed) GetRankings(ctx context.Context, from, to time.Time) ([]*RankingsResult, error) {
+	buckets, err := w.bucketsIn(ctx, from, to)
+	if err != nil || len(buckets) == 0 {
+		return nil, err
+	}
+
+	results, err := w.s.GetMultiRankingsContext(ctx, buckets)
+	if err != nil {
+		return nil, err
+	}
+	size := defaultRankSize
+	if w.opts.Properties != nil && w.opts.Properties.RankingsProperties.Size > 0 {
+		size = w.opts.Properties.RankingsProperties.Size
+	}
+	return mergeRankings(results, int(size)), nil
+}
+
+func mergeRankings(rankings [][]*RankingsResult, size int) []*RankingsResult {
+	counts := make(map[string]int64)
+	for _, r := range rankings {
+		for _, rank := range r {
+			counts[rank.Value] += rank.Count
+		}
+	}
+
+	ret := make([]*RankingsResult, 0, len(counts))
+	for v, c := range counts {
+		ret = append(ret, &RankingsResult{Value: v, Count: c})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return ret[i].Value < ret[j].Value
+	})
+	if len(ret) > size {
+		ret = ret[:size]
+	}
+	return ret
+}
+
+// GetCardinality sums the cardinality of the buckets overlapping [from, to). Values seen
+// in several buckets are counted once per bucket, so the sum is an upper bound of the
+// distinct values in the range.
+func (w *Windowed) GetCardinality(ctx context.Context, from, to time.Time) (int64, error) {
+	buckets, err := w.bucketsIn(ctx, from, to)
+	if err != nil || len(buckets) == 0 {
+		return 0, err
+	}
+
+	results, err := w.s.GetMultiCardinalityContext(ctx, buckets)
+	if err != nil {
+		return 0, err
+	}
+	var sum int64
+	for _, c := range results {
+		sum += c
+	}
+	return sum, nil
+}
@@ -0,0 +1,83 @@
+package skizze_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	. "github.com/skizzehq/goskizze/skizze"
+)
+
+func TestWindowed(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	l := NewLocal()
+	now := time.Date(2026, 10, 18, 3, 30, 0, 0, time.UTC)
+	w := NewWindowed(l, "users", WindowOptions{
+		Retention: 2 * time.Hour,
+		Ahead:     1,
+		Now:       func() time.Time { return now },
+	})
+	assert.Equal("users:2026101803", w.BucketName(now))
+
+	assert.Nil(w.AddAt(ctx, now.Add(-2*time.Hour), "alvin", "simon"))
+	assert.Nil(w.AddAt(ctx, now.Add(-time.Hour), "alvin", "theodore"))
+	assert.Nil(w.Add(ctx, "alvin", "alvin", "claire"))
+
+	domains, err := l.ListDomains()
+	assert.Nil(err)
+	assert.Equal([]string{"users:2026101801", "users:2026101802", "users:2026101803"}, domains)
+
+	from, to := now.Add(-90*time.Minute), now
+	membs, err := w.GetMembership(ctx, from, to, "simon", "theodore", "claire")
+	assert.Nil(err)
+	assert.False(membs[0].IsMember)
+	assert.True(membs[1].IsMember)
+	assert.True(membs[2].IsMember)
+
+	freqs, err := w.GetFrequency(ctx, from, to, "alvin", "gary")
+	assert.Nil(err)
+	assert.Equal(int64(3), freqs[0].Count)
+	assert.Equal(int64(0), freqs[1].Count)
+
+	ranks, err := w.GetRankings(ctx, now.Add(-24*time.Hour), now.Add(time.Hour))
+	assert.Nil(err)
+	assert.Equal("alvin", ranks[0].Value)
+	assert.Equal(int64(4), ranks[0].Count)
+	assert.Equal(4, len(ranks))
+
+	card, err := w.GetCardinality(ctx, from, to)
+	assert.Nil(err)
+	assert.Equal(int64(4), card)
+
+	card, err = w.GetCardinality(ctx, now.Add(time.Hour), now.Add(2*time.Hour))
+	assert.Nil(err)
+	assert.Equal(int64(0), card)
+
+	// Maintain creates the next bucket and deletes those which ended over 2h ago.
+	now = now.Add(time.Hour)
+	assert.Nil(w.Maintain(ctx))
+	domains, err = l.ListDomains()
+	assert.Nil(err)
+	assert.Equal([]string{"users:2026101802", "users:2026101803", "users:2026101804", "users:2026101805"}, domains)
+}
+
+func TestWindowedErrors(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	l := NewLocal()
+	w := NewWindowed(l, "users", WindowOptions{
+		Bucket:     24 * time.Hour,
+		Properties: &DomainProperties{},
+	})
+	assert.True(errors.Is(w.Add(ctx, "alvin"), ErrInvalidProperties))
+
+	w = NewWindowed(l, "users", WindowOptions{Bucket: 24 * time.Hour})
+	assert.Equal("users:20261018", w.BucketName(time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC)))
+	assert.Nil(w.Add(ctx, "alvin"))
+}